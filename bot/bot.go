@@ -3,8 +3,11 @@ package bot
 import (
 	"context"
 	"discord-bot-tickets/bot/listeners"
+	"discord-bot-tickets/bot/scheduler"
 	"discord-bot-tickets/bot/services"
 	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
 	"log"
 
 	"github.com/diamondburned/arikawa/v3/api/cmdroute"
@@ -12,13 +15,17 @@ import (
 	"github.com/diamondburned/arikawa/v3/state"
 )
 
-func InitializeBot(config *config.Config) {
+func InitializeBot(config *config.Config, store database.Store) {
 	var intents = []gateway.Intents{
 		gateway.IntentGuilds,
 		gateway.IntentGuildMessages,
 		gateway.IntentDirectMessages,
 	}
 
+	if !config.Permissions.Enabled() {
+		logger.Warn("No permission roles configured, nobody can use the staff commands until PERMISSION_SUPPORTER_ROLES, PERMISSION_MODERATOR_ROLES or PERMISSION_ADMIN_ROLES is set")
+	}
+
 	router := cmdroute.NewRouter()
 
 	botState := state.New("Bot " + config.Discord.Token)
@@ -29,10 +36,12 @@ func InitializeBot(config *config.Config) {
 	}
 
 	// Create bot service
-	botService := services.NewBotService(config, botState)
+	botService := services.NewBotService(config, botState, store)
 
 	RegisterCommands(router, botService)
 	listeners.RegisterListeners(botService)
+	scheduler.Start(context.TODO(), botService)
+	ReportCommandTimings(context.TODO())
 
 	if err := botState.Connect(context.TODO()); err != nil {
 		log.Println("cannot connect:", err)
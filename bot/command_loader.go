@@ -8,34 +8,62 @@ import (
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
 )
 
-// CommandHandler represents a Discord command handler
-type CommandHandler func(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData
-
-// CommandRegistry holds all registered commands
-var CommandRegistry = map[string]CommandHandler{
-	"reply": commands.ReplyCommand,
-	"close": commands.CloseCommand,
-}
+// RegisterCommands loads and registers all commands, see commands.Register
+func RegisterCommands(router *cmdroute.Router, service *services.BotService) {
+	all := commands.All()
 
-// CommandData holds all command data
-var commandData = []api.CreateCommandData{
-	{Name: "reply", Description: commands.GetReplyDescription(), DescriptionLocalizations: commands.GetReplyLocale(), Options: commands.GetReplyOptions()},
-	{Name: "close", Description: commands.GetCloseDescription(), DescriptionLocalizations: commands.GetCloseLocale()},
-}
+	commandData := make([]api.CreateCommandData, 0, len(all))
+	for _, command := range all {
+		registerCommand(router, service, command.Name, command)
+		commandData = append(commandData, command.CreateData())
+	}
 
-// RegisterCommands loads and registers all commands
-func RegisterCommands(router *cmdroute.Router, service *services.BotService) {
-	for name, handler := range CommandRegistry {
-		router.AddFunc(name, func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	for id, component := range commands.Components() {
+		handler := ChainComponent(component, component.Handler, DefaultComponentMiddleware...)
+		router.AddComponentFunc(id, func(ctx context.Context, data cmdroute.ComponentData) *api.InteractionResponse {
 			return handler(ctx, service, data)
 		})
 	}
 
-	if err := cmdroute.OverwriteCommands(service.State(), commandData); err != nil {
+	changes, err := syncCommands(service.State(), service.Config().Discord.GuildID, commandData)
+	if err != nil {
 		log.Fatalln("cannot update commands:", err)
 	}
 
-	log.Printf("Registered %d commands", len(CommandRegistry))
+	log.Printf("Registered %d commands: %d created, %d updated, %d deleted, %d unchanged",
+		len(all), changes.Created, changes.Updated, changes.Deleted, changes.Unchanged)
+}
+
+// registerCommand adds the handlers of a command to the router under the given name. A command
+// with subcommands gets a router of its own, where each subcommand inherits the settings of the command.
+func registerCommand(router *cmdroute.Router, service *services.BotService, name string, command *commands.Command) {
+	// The router matches subcommands on their own name, the full name is only used by the middleware
+	if len(command.Subcommands) > 0 {
+		router.Sub(name, func(r *cmdroute.Router) {
+			for _, sub := range command.Subcommands {
+				registerCommand(r, service, sub.Name, sub.Inherit(command))
+			}
+		})
+		return
+	}
+
+	// Deferred responses are ephemeral, or the replies meant for the staff member alone would show up in the channel
+	if command.Deferred {
+		router = router.With(cmdroute.Deferrable(service.State(), cmdroute.DeferOpts{Flags: discord.EphemeralMessage}))
+	}
+
+	handler := Chain(command, command.Handler, commandMiddleware(command)...)
+	router.AddFunc(name, func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		return handler(ctx, service, data)
+	})
+
+	if command.Autocomplete != nil {
+		autocomplete := RequireAutocompletePermission(command, command.Autocomplete)
+		router.AddAutocompleterFunc(name, func(ctx context.Context, data cmdroute.AutocompleteData) api.AutocompleteChoices {
+			return autocomplete(ctx, service, data)
+		})
+	}
 }
@@ -0,0 +1,213 @@
+package bot
+
+import (
+	logger "discord-bot-tickets/logging"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+)
+
+// commandKey identifies a command, slash commands and context menu commands may share a name
+type commandKey struct {
+	Type discord.CommandType
+	Name string
+}
+
+// keyOf gets the commandKey of a command definition
+func keyOf(data api.CreateCommandData) commandKey {
+	commandType := data.Type
+	if commandType == 0 {
+		commandType = discord.ChatInputCommand
+	}
+
+	return commandKey{Type: commandType, Name: data.Name}
+}
+
+// commandChanges counts what syncCommands did
+type commandChanges struct {
+	Created   int
+	Updated   int
+	Deleted   int
+	Unchanged int
+}
+
+// syncCommands registers the commands in the guild of the bot. The commands already in the
+// guild are compared with the desired ones, and only what changed is created, updated or
+// deleted. Global commands left from before commands were guild scoped are removed.
+//
+// Returns: the changes made and an error if any
+func syncCommands(state *state.State, guildID discord.GuildID, desired []api.CreateCommandData) (commandChanges, error) {
+	var changes commandChanges
+
+	app, err := state.CurrentApplication()
+	if err != nil {
+		return changes, fmt.Errorf("cannot get the current application: %w", err)
+	}
+
+	if err := removeGlobalCommands(state, app.ID); err != nil {
+		return changes, err
+	}
+
+	existing, err := state.GuildCommands(app.ID, guildID)
+	if err != nil {
+		return changes, fmt.Errorf("cannot get the guild commands: %w", err)
+	}
+
+	registered := make(map[commandKey]discord.Command, len(existing))
+	for _, command := range existing {
+		registered[keyOf(existingData(command))] = command
+	}
+
+	for _, data := range desired {
+		key := keyOf(data)
+
+		command, ok := registered[key]
+		delete(registered, key)
+
+		switch {
+		case !ok:
+			if _, err := state.CreateGuildCommand(app.ID, guildID, data); err != nil {
+				return changes, fmt.Errorf("cannot create command %s: %w", data.Name, err)
+			}
+			logger.Info("Created command %s", data.Name)
+			changes.Created++
+		case !sameCommand(existingData(command), data):
+			if _, err := state.EditGuildCommand(app.ID, guildID, command.ID, data); err != nil {
+				return changes, fmt.Errorf("cannot update command %s: %w", data.Name, err)
+			}
+			logger.Info("Updated command %s", data.Name)
+			changes.Updated++
+		default:
+			changes.Unchanged++
+		}
+	}
+
+	// Whatever is left is no longer defined
+	for _, command := range registered {
+		if err := state.DeleteGuildCommand(app.ID, guildID, command.ID); err != nil {
+			return changes, fmt.Errorf("cannot delete command %s: %w", command.Name, err)
+		}
+		logger.Info("Deleted command %s", command.Name)
+		changes.Deleted++
+	}
+
+	return changes, nil
+}
+
+// removeGlobalCommands deletes the global commands of the application, which would otherwise
+// show up next to the guild commands
+//
+// Returns: an error if any
+func removeGlobalCommands(state *state.State, appID discord.AppID) error {
+	global, err := state.Commands(appID)
+	if err != nil {
+		return fmt.Errorf("cannot get the global commands: %w", err)
+	}
+
+	if len(global) == 0 {
+		return nil
+	}
+
+	if _, err := state.BulkOverwriteCommands(appID, []api.CreateCommandData{}); err != nil {
+		return fmt.Errorf("cannot remove the global commands: %w", err)
+	}
+
+	logger.Info("Removed %d global commands, commands are registered per guild now", len(global))
+
+	return nil
+}
+
+// existingData turns a registered command back into the definition it was created from
+func existingData(command discord.Command) api.CreateCommandData {
+	return api.CreateCommandData{
+		Name:                     command.Name,
+		NameLocalizations:        command.NameLocalizations,
+		Description:              command.Description,
+		DescriptionLocalizations: command.DescriptionLocalizations,
+		Options:                  command.Options,
+		DefaultMemberPermissions: command.DefaultMemberPermissions,
+		Type:                     command.Type,
+	}
+}
+
+// sameCommand compares two command definitions. Discord leaves out empty and false fields
+// and fills in defaults, so both are compared in their JSON form without those.
+func sameCommand(a, b api.CreateCommandData) bool {
+	normalizedA, errA := normalizeCommand(a)
+	normalizedB, errB := normalizeCommand(b)
+
+	// A definition that can't be compared is sent again, to be safe
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(normalizedA, normalizedB)
+}
+
+// normalizeCommand gets the JSON form of a command definition without the fields that
+// don't change what the command looks like, and without empty values
+func normalizeCommand(data api.CreateCommandData) (any, error) {
+	data.ID = 0
+	data.Type = keyOf(data).Type
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+
+	// Only sent on creation, never returned by Discord
+	delete(decoded, "dm_permission")
+	delete(decoded, "default_permission")
+
+	return pruneEmpty(decoded), nil
+}
+
+// pruneEmpty removes false, zero length and null values from decoded JSON, recursively
+func pruneEmpty(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			item = pruneEmpty(item)
+			if isEmpty(item) {
+				delete(value, key)
+				continue
+			}
+			value[key] = item
+		}
+		return value
+	case []any:
+		for i, item := range value {
+			value[i] = pruneEmpty(item)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// isEmpty checks if a decoded JSON value is false, empty or null
+func isEmpty(value any) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !value
+	case string:
+		return value == ""
+	case []any:
+		return len(value) == 0
+	case map[string]any:
+		return len(value) == 0
+	default:
+		return false
+	}
+}
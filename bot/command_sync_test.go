@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"discord-bot-tickets/bot/commands"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestSameCommand(t *testing.T) {
+	manageMessages := discord.PermissionManageMessages
+	manageGuild := discord.PermissionManageGuild
+
+	// command is a slash command with a required and an optional option
+	command := func() api.CreateCommandData {
+		return api.CreateCommandData{
+			Name:        "reply",
+			Description: "Reply to the user",
+			Options: discord.CommandOptions{
+				&discord.StringOption{OptionName: "message", Description: "The reply", Required: true},
+				&discord.BooleanOption{OptionName: "anonymous", Description: "Hide your name"},
+			},
+			DefaultMemberPermissions: &manageMessages,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		change func(data *api.CreateCommandData)
+		want   bool
+	}{
+		{name: "equal definitions", change: func(data *api.CreateCommandData) {}, want: true},
+		{name: "ID set by Discord", change: func(data *api.CreateCommandData) { data.ID = 123 }, want: true},
+		{name: "default type filled in", change: func(data *api.CreateCommandData) { data.Type = discord.ChatInputCommand }, want: true},
+		{name: "empty instead of omitted localizations", change: func(data *api.CreateCommandData) {
+			data.NameLocalizations = discord.StringLocales{}
+			data.DescriptionLocalizations = discord.StringLocales{}
+		}, want: true},
+		{name: "empty instead of omitted option choices", change: func(data *api.CreateCommandData) {
+			data.Options[0] = &discord.StringOption{OptionName: "message", Description: "The reply", Required: true, Choices: []discord.StringChoice{}}
+		}, want: true},
+		{name: "only sent on creation", change: func(data *api.CreateCommandData) { data.NoDMPermission = true }, want: true},
+		{name: "differing option order", change: func(data *api.CreateCommandData) {
+			data.Options[0], data.Options[1] = data.Options[1], data.Options[0]
+		}, want: false},
+		{name: "differing description", change: func(data *api.CreateCommandData) { data.Description = "Reply" }, want: false},
+		{name: "option no longer required", change: func(data *api.CreateCommandData) {
+			data.Options[0] = &discord.StringOption{OptionName: "message", Description: "The reply"}
+		}, want: false},
+		{name: "option limit added", change: func(data *api.CreateCommandData) {
+			data.Options[0] = &discord.StringOption{OptionName: "message", Description: "The reply", Required: true, MaxLength: option.NewInt(100)}
+		}, want: false},
+		{name: "differing member permissions", change: func(data *api.CreateCommandData) { data.DefaultMemberPermissions = &manageGuild }, want: false},
+		{name: "member permissions removed", change: func(data *api.CreateCommandData) { data.DefaultMemberPermissions = nil }, want: false},
+		{name: "differing type", change: func(data *api.CreateCommandData) { data.Type = discord.MessageCommand }, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := command()
+			tt.change(&changed)
+
+			if got := sameCommand(command(), changed); got != tt.want {
+				t.Errorf("sameCommand = %v, want %v", got, tt.want)
+			}
+			if got := sameCommand(changed, command()); got != tt.want {
+				t.Errorf("sameCommand with the definitions swapped = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruneEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{name: "false instead of omitted", a: `{"name":"reply","options":[{"type":3,"name":"message","required":false}]}`, b: `{"name":"reply","options":[{"type":3,"name":"message"}]}`, want: true},
+		{name: "null instead of omitted", a: `{"name":"reply","description_localizations":null}`, b: `{"name":"reply"}`, want: true},
+		{name: "empty list instead of omitted", a: `{"name":"reply","options":[]}`, b: `{"name":"reply"}`, want: true},
+		{name: "empty object instead of omitted", a: `{"name":"reply","name_localizations":{}}`, b: `{"name":"reply"}`, want: true},
+		{name: "empty string instead of omitted", a: `{"name":"reply","description":""}`, b: `{"name":"reply"}`, want: true},
+		{name: "true instead of omitted", a: `{"name":"reply","options":[{"type":3,"name":"message","required":true}]}`, b: `{"name":"reply","options":[{"type":3,"name":"message"}]}`, want: false},
+		{name: "zero number is kept", a: `{"name":"reply","options":[{"type":4,"name":"count","min_value":0}]}`, b: `{"name":"reply","options":[{"type":4,"name":"count"}]}`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a, b any
+			if err := json.Unmarshal([]byte(tt.a), &a); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.b), &b); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			if got := reflect.DeepEqual(pruneEmpty(a), pruneEmpty(b)); got != tt.want {
+				t.Errorf("equal after pruning = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSameCommandAfterRegistering(t *testing.T) {
+	// Every command should be unchanged once Discord sends it back, or it is updated on every start
+	for _, command := range commands.All() {
+		t.Run(command.Name, func(t *testing.T) {
+			data := command.CreateData()
+
+			raw, err := json.Marshal(data)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+
+			var registered discord.Command
+			if err := json.Unmarshal(raw, &registered); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			if !sameCommand(existingData(registered), data) {
+				t.Errorf("registered command differs from its definition")
+			}
+		})
+	}
+}
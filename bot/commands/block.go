@@ -0,0 +1,213 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/colors"
+	"discord-bot-tickets/bot/commands/helpers/duration"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// maxBlocklistLength keeps the blocklist within a single embed description
+const maxBlocklistLength = 4000
+
+func BlockCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	userID, err := data.Options.Find("user").SnowflakeValue()
+	if err != nil {
+		return blockError(err)
+	}
+
+	if user, ok := data.Data.Resolved.Users[discord.UserID(userID)]; ok && user.Bot {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.block.bot")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	block := &database.Block{
+		UserID:    discord.UserID(userID),
+		BlockedBy: data.Event.Member.User.ID,
+		Reason:    data.Options.Find("reason").String(),
+	}
+
+	if value := data.Options.Find("duration").String(); value != "" {
+		blockFor, err := duration.Parse(value)
+		if err != nil {
+			return &api.InteractionResponseData{
+				Content: option.NewNullableString(language.GetTranslation("commands.block.invalid_duration")),
+				Flags:   discord.EphemeralMessage,
+			}
+		}
+
+		expiresAt := time.Now().Add(blockFor)
+		block.ExpiresAt = &expiresAt
+	}
+
+	if err := service.Store().Blocks().Block(block); err != nil {
+		return blockError(err)
+	}
+
+	logger.Info("User %s was blocked by %s", block.UserID, block.BlockedBy)
+
+	content := fmt.Sprintf(language.GetTranslation("commands.block.success"), block.UserID.Mention())
+	if block.ExpiresAt != nil {
+		content = fmt.Sprintf(language.GetTranslation("commands.block.success_until"), block.UserID.Mention(), discordTimestamp(*block.ExpiresAt))
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(content),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func UnblockCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	userID, err := data.Options.Find("user").SnowflakeValue()
+	if err != nil {
+		return blockError(err)
+	}
+
+	unblocked, err := service.Store().Blocks().Unblock(discord.UserID(userID))
+	if err != nil {
+		return blockError(err)
+	}
+
+	if !unblocked {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.block.not_blocked"), discord.UserID(userID).Mention())),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	logger.Info("User %s was unblocked by %s", discord.UserID(userID), data.Event.Member.User.ID)
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.block.unblocked"), discord.UserID(userID).Mention())),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func BlocklistCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	blocks, err := service.Store().Blocks().ListActive(time.Now())
+	if err != nil {
+		return blockError(err)
+	}
+
+	if len(blocks) == 0 {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.block.empty")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	var (
+		lines  []string
+		length int
+	)
+	for i, block := range blocks {
+		line := blocklistLine(block)
+
+		length += utf8.RuneCountInString(line) + 1
+		if length > maxBlocklistLength {
+			lines = append(lines, fmt.Sprintf(language.GetTranslation("commands.block.more"), len(blocks)-i))
+			break
+		}
+
+		lines = append(lines, line)
+	}
+
+	return &api.InteractionResponseData{
+		Embeds: &[]discord.Embed{{
+			Title:       language.GetTranslation("commands.block.list_title"),
+			Description: strings.Join(lines, "\n"),
+			Color:       colors.GetColor(colors.Red),
+		}},
+		Flags: discord.EphemeralMessage,
+	}
+}
+
+// blocklistLine describes a single block for the blocklist
+func blocklistLine(block database.Block) string {
+	until := language.GetTranslation("commands.block.permanent")
+	if block.ExpiresAt != nil {
+		until = discordTimestamp(*block.ExpiresAt)
+	}
+
+	line := fmt.Sprintf("%s • %s • %s", block.UserID.Mention(), until, block.BlockedBy.Mention())
+	if block.Reason != "" {
+		line += " • " + block.Reason
+	}
+
+	return line
+}
+
+// discordTimestamp formats a time so Discord shows it in the reader's own time zone
+func discordTimestamp(t time.Time) string {
+	return fmt.Sprintf("<t:%d:f>", t.Unix())
+}
+
+// blockError logs a storage error and responds with a generic error
+func blockError(err error) *api.InteractionResponseData {
+	logger.Error(err.Error())
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation("commands.block.error")),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func init() {
+	Register(&Command{
+		Name:        "block",
+		Description: "Stop a user from opening or replying to tickets",
+		Permission:  config.PermissionModerator,
+		Handler:     BlockCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.UserOption{
+				OptionName:  "user",
+				Description: "The user to block",
+				Required:    true,
+			},
+			&discord.StringOption{
+				OptionName:  "duration",
+				Description: "Lift the block after this long, e.g. 12h or 7d. Permanent if left out",
+			},
+			&discord.StringOption{
+				OptionName:  "reason",
+				Description: "The reason for the block, only shown to staff",
+			},
+		},
+	})
+
+	Register(&Command{
+		Name:        "unblock",
+		Description: "Let a blocked user open tickets again",
+		Permission:  config.PermissionModerator,
+		Handler:     UnblockCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.UserOption{
+				OptionName:  "user",
+				Description: "The user to unblock",
+				Required:    true,
+			},
+		},
+	})
+
+	Register(&Command{
+		Name:        "blocklist",
+		Description: "List the users that are currently blocked",
+		Permission:  config.PermissionModerator,
+		Handler:     BlocklistCommand,
+	})
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/commands/helpers/permissions"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func ClaimCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	record := tickets.FromContext(ctx).Record
+
+	staff := data.Event.Member.User
+
+	if record.ClaimedBy == staff.ID {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.claim.already_yours")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	if record.ClaimedBy.IsValid() {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.claim.taken"), record.ClaimedBy.Mention())),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	if err := tickets.AssignTicket(service.State(), service.Store(), record, staff.ID); err != nil {
+		return claimError(err)
+	}
+
+	// Not ephemeral, so the rest of the staff can see who is handling the ticket
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.claim.claimed"), staff.Mention())),
+		AllowedMentions: &api.AllowedMentions{},
+	}
+}
+
+func UnclaimCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	record := tickets.FromContext(ctx).Record
+
+	if !record.ClaimedBy.IsValid() {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.claim.not_claimed")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	previous := record.ClaimedBy
+
+	if err := tickets.AssignTicket(service.State(), service.Store(), record, discord.NullUserID); err != nil {
+		return claimError(err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.claim.unclaimed"), data.Event.Member.User.Mention(), previous.Mention())),
+		AllowedMentions: &api.AllowedMentions{},
+	}
+}
+
+func AssignCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	staffID, err := data.Options.Find("staff").SnowflakeValue()
+	if err != nil {
+		return claimError(err)
+	}
+
+	if user, ok := data.Data.Resolved.Users[discord.UserID(staffID)]; ok && user.Bot {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.claim.bot")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	// Resolved members only exist for members of the server, and carry their roles
+	member, ok := data.Data.Resolved.Members[discord.UserID(staffID)]
+	if !ok || !permissions.Allowed(service.Config(), &member, config.PermissionSupporter) {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.claim.not_staff"), discord.UserID(staffID).Mention())),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	record := tickets.FromContext(ctx).Record
+
+	if err := tickets.AssignTicket(service.State(), service.Store(), record, discord.UserID(staffID)); err != nil {
+		return claimError(err)
+	}
+
+	// The assignee is pinged, so they notice the ticket was handed to them
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.claim.assigned"), data.Event.Member.User.Mention(), discord.UserID(staffID).Mention())),
+		AllowedMentions: &api.AllowedMentions{
+			Users: []discord.UserID{discord.UserID(staffID)},
+		},
+	}
+}
+
+// claimError logs an error and responds with a generic error
+func claimError(err error) *api.InteractionResponseData {
+	logger.Error(err.Error())
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation("commands.claim.error")),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func init() {
+	Register(&Command{
+		Name:        "claim",
+		Description: "Take this ticket, so other staff know you are handling it",
+		Permission:  config.PermissionSupporter,
+		InTicket:    true,
+		Handler:     ClaimCommand,
+	})
+
+	Register(&Command{
+		Name:        "unclaim",
+		Description: "Release this ticket, so another staff member can take it",
+		Permission:  config.PermissionSupporter,
+		InTicket:    true,
+		Handler:     UnclaimCommand,
+	})
+
+	Register(&Command{
+		Name:        "assign",
+		Description: "Hand this ticket to a staff member",
+		Permission:  config.PermissionModerator,
+		InTicket:    true,
+		Handler:     AssignCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.UserOption{
+				OptionName:  "staff",
+				Description: "The staff member who should handle the ticket",
+				Required:    true,
+			},
+		},
+	})
+}
@@ -2,10 +2,13 @@ package commands
 
 import (
 	"context"
+	"discord-bot-tickets/bot/commands/helpers/duration"
 	"discord-bot-tickets/bot/commands/helpers/language"
 	"discord-bot-tickets/bot/services"
 	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
 	logger "discord-bot-tickets/logging"
+	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/api/cmdroute"
@@ -14,95 +17,111 @@ import (
 )
 
 func CloseCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
-	// Get the channel where the command was used
-	channel, err := service.State().Channel(data.Event.ChannelID)
-	if err != nil {
-		return &api.InteractionResponseData{
-			Content: option.NewNullableString(language.GetTranslation("general.errors.channel")),
-			Flags:   discord.EphemeralMessage,
-		}
+	reason := data.Options.Find("reason").String()
+
+	silent, _ := data.Options.Find("silent").BoolValue()
+
+	ticket := tickets.FromContext(ctx)
+
+	if cancel, _ := data.Options.Find("cancel").BoolValue(); cancel {
+		return cancelScheduledClose(service, ticket)
 	}
 
-	// Check if this is a ticket channel
-	isTicket, err := tickets.IsChannelTicket(*service.State(), channel)
-	if err != nil {
-		return &api.InteractionResponseData{
-			Content: option.NewNullableString(language.GetTranslation("general.errors.generic")),
-			Flags:   discord.EphemeralMessage,
-		}
+	options := tickets.CloseOptions{
+		Closer: data.Event.Member.User,
+		Reason: reason,
+		Silent: silent,
 	}
 
-	if !isTicket {
+	if delay := data.Options.Find("in").String(); delay != "" {
+		return scheduleClose(service, ticket, delay, options)
+	}
+
+	if err := tickets.CloseTicket(service.Config(), service.State(), service.Store(), ticket.Channel, ticket.Author.ID, options); err != nil {
+		logger.Error(err.Error())
 		return &api.InteractionResponseData{
-			Content: option.NewNullableString(language.GetTranslation("general.errors.not_a_ticket")),
+			Content: option.NewNullableString(language.GetTranslation("commands.close.error")),
 			Flags:   discord.EphemeralMessage,
 		}
 	}
 
-	// Get the ticket owner from the channel topic
-	ticketOwner, err := tickets.GetAuthorFromChannel(service.State(), channel)
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation("commands.close.success")),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+// scheduleClose schedules a ticket to be closed after the given delay
+func scheduleClose(service *services.BotService, ticket *tickets.Ticket, delay string, options tickets.CloseOptions) *api.InteractionResponseData {
+	closeIn, err := duration.Parse(delay)
 	if err != nil {
 		return &api.InteractionResponseData{
-			Content: option.NewNullableString(language.GetTranslation("general.errors.owner")),
+			Content: option.NewNullableString(language.GetTranslation("commands.close.invalid_duration")),
 			Flags:   discord.EphemeralMessage,
 		}
 	}
 
-	if ticketOwner == nil {
+	if err := tickets.ScheduleClose(service.State(), service.Store(), ticket, closeIn, options); err != nil {
+		logger.Error(err.Error())
 		return &api.InteractionResponseData{
-			Content: option.NewNullableString(language.GetTranslation("general.errors.not_a_ticket")),
+			Content: option.NewNullableString(language.GetTranslation("commands.close.error")),
 			Flags:   discord.EphemeralMessage,
 		}
 	}
 
-	// Create an embed to notify the user
-	embed := discord.Embed{
-		Title:       language.GetTranslation("embeds.ticket_closed.title"),
-		Description: language.GetTranslation("embeds.ticket_closed.description"),
-		Color:       0xFF0000, // Red color
-		Footer: &discord.EmbedFooter{
-			Text: language.GetTranslation("embeds.ticket_closed.footer"),
-		},
+	// Not ephemeral, so everyone in the ticket channel can see the close is pending
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.close.scheduled"), duration.Format(closeIn))),
 	}
+}
 
-	// Create DM channel with the user
-	dmChannel, err := service.State().CreatePrivateChannel(ticketOwner.ID)
+// cancelScheduledClose cancels the scheduled close of a ticket
+func cancelScheduledClose(service *services.BotService, ticket *tickets.Ticket) *api.InteractionResponseData {
+	cancelled, err := tickets.CancelScheduledClose(service.Store(), ticket)
 	if err != nil {
-		logger.Error("Failed to create DM channel with user: " + err.Error())
-	} else {
-		// Send the embed to the user
-		_, err = service.State().SendMessage(dmChannel.ID, "", embed)
-		if err != nil {
-			logger.Error("Failed to send close notification to user: " + err.Error())
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("general.errors.generic")),
+			Flags:   discord.EphemeralMessage,
 		}
 	}
 
-	// Log the ticket closure
-	logger.Info("Ticket closed by staff member " + data.Event.Member.User.ID.String())
-
-	// Delete the channel with audit log reason
-	err = service.State().DeleteChannel(channel.ID, api.AuditLogReason("Ticket closed by "+data.Event.Member.User.Tag()))
-	if err != nil {
+	if !cancelled {
 		return &api.InteractionResponseData{
-			Content: option.NewNullableString(language.GetTranslation("commands.close.error")),
+			Content: option.NewNullableString(language.GetTranslation("commands.close.not_scheduled")),
 			Flags:   discord.EphemeralMessage,
 		}
 	}
 
 	return &api.InteractionResponseData{
-		Content: option.NewNullableString(language.GetTranslation("commands.close.success")),
-		Flags:   discord.EphemeralMessage,
+		Content: option.NewNullableString(language.GetTranslation("commands.close.cancelled")),
 	}
 }
 
-func GetCloseLocale() map[discord.Language]string {
-	return map[discord.Language]string{}
-}
-
-func GetCloseDescription() string {
-	return "Close a ModMail ticket"
-}
-
-func GetCloseOptions() discord.CommandOptions {
-	return discord.CommandOptions{}
+func init() {
+	Register(&Command{
+		Name:        "close",
+		Description: "Close a ModMail ticket",
+		Permission:  config.PermissionSupporter,
+		InTicket:    true,
+		Handler:     CloseCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.StringOption{
+				OptionName:  "reason",
+				Description: "The reason for closing the ticket",
+			},
+			&discord.BooleanOption{
+				OptionName:  "silent",
+				Description: "Keep the reason visible to staff only",
+			},
+			&discord.StringOption{
+				OptionName:  "in",
+				Description: "Close the ticket after this long unless the user replies, e.g. 30m, 2h or 1d",
+			},
+			&discord.BooleanOption{
+				OptionName:  "cancel",
+				Description: "Cancel the scheduled close of this ticket",
+			},
+		},
+	})
 }
@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/config"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// CommandHandler represents a Discord command handler
+type CommandHandler func(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData
+
+// AutocompleteHandler represents a Discord autocomplete handler
+type AutocompleteHandler func(ctx context.Context, service *services.BotService, data cmdroute.AutocompleteData) api.AutocompleteChoices
+
+// ComponentHandler represents a Discord message component handler
+type ComponentHandler func(ctx context.Context, service *services.BotService, data cmdroute.ComponentData) *api.InteractionResponse
+
+// Component is a registered message component handler
+type Component struct {
+	// ID is the custom ID of the component
+	ID string
+	// Permission is the level of the command that sends the component, everyone for
+	// components no command owns
+	Permission config.PermissionLevel
+	Handler    ComponentHandler
+}
+
+// Command describes a slash command or a context menu command, with everything needed to
+// register and run it. A command either has a handler or subcommands. A subcommand with
+// subcommands of its own is a subcommand group, e.g. /settings roles add.
+type Command struct {
+	// Type is the kind of command, a slash command unless set. Context menu commands have
+	// no description, options or subcommands, and their name may contain spaces.
+	Type                     discord.CommandType
+	Name                     string
+	Description              string
+	DescriptionLocalizations discord.StringLocales
+	Options                  []discord.CommandOptionValue
+	// Permission is the level needed to use the command. Subcommands need at least
+	// the level of their parent, so they can only raise it.
+	Permission config.PermissionLevel
+	// InTicket only allows the command in open ticket channels, and passes the ticket
+	// to the handler, where it is read with tickets.FromContext. Subcommands inherit it.
+	InTicket bool
+	// Deferred lets the handler take longer than Discord waits for a response, for commands
+	// that relay attachments or build transcripts. The response of a deferred command is
+	// always ephemeral. Subcommands inherit it.
+	Deferred     bool
+	Handler      CommandHandler
+	Autocomplete AutocompleteHandler
+	Subcommands  []*Command
+	// Components are the handlers of message components the command sends, keyed by custom ID
+	Components map[string]ComponentHandler
+}
+
+// Inherit gets the subcommand as it runs within its parent, with its full name, e.g.
+// "snippet add", and the permission level, ticket requirement and deferral of the parent applied
+//
+// Returns: a pointer to a copy of the subcommand
+func (c *Command) Inherit(parent *Command) *Command {
+	inherited := *c
+	inherited.Name = parent.Name + " " + c.Name
+	inherited.Permission = max(c.Permission, parent.Permission)
+	inherited.InTicket = c.InTicket || parent.InTicket
+	inherited.Deferred = c.Deferred || parent.Deferred
+
+	return &inherited
+}
+
+// memberPermissions gets the Discord permissions a member needs to see a command of a
+// permission level. This only hides staff commands from regular members, server admins
+// can still change it in the integration settings, the configured roles are always checked.
+//
+// Returns: the permissions, or nil if everyone can see the command
+func memberPermissions(level config.PermissionLevel) *discord.Permissions {
+	var permissions discord.Permissions
+
+	switch {
+	case level >= config.PermissionAdmin:
+		permissions = discord.PermissionManageGuild
+	case level >= config.PermissionModerator:
+		permissions = discord.PermissionModerateMembers
+	case level >= config.PermissionSupporter:
+		permissions = discord.PermissionManageMessages
+	default:
+		return nil
+	}
+
+	return &permissions
+}
+
+// CreateData builds the definition of the command that is sent to Discord
+//
+// Returns: the api.CreateCommandData of the command
+func (c *Command) CreateData() api.CreateCommandData {
+	if c.IsContextMenu() {
+		return api.CreateCommandData{
+			Name:                     c.Name,
+			Type:                     c.Type,
+			DefaultMemberPermissions: memberPermissions(c.Permission),
+		}
+	}
+
+	options := make(discord.CommandOptions, 0, max(len(c.Options), len(c.Subcommands)))
+
+	if len(c.Subcommands) > 0 {
+		for _, sub := range c.Subcommands {
+			options = append(options, sub.option())
+		}
+	} else {
+		for _, value := range c.Options {
+			options = append(options, value)
+		}
+	}
+
+	return api.CreateCommandData{
+		Name:                     c.Name,
+		Description:              c.Description,
+		DescriptionLocalizations: c.DescriptionLocalizations,
+		Options:                  options,
+		DefaultMemberPermissions: memberPermissions(c.Permission),
+	}
+}
+
+// IsContextMenu checks if the command is used from the context menu of a message or user
+func (c *Command) IsContextMenu() bool {
+	return c.Type == discord.MessageCommand || c.Type == discord.UserCommand
+}
+
+// option builds the definition of a subcommand, or of a subcommand group if it has subcommands
+func (c *Command) option() discord.CommandOption {
+	if len(c.Subcommands) == 0 {
+		return &discord.SubcommandOption{
+			OptionName:               c.Name,
+			Description:              c.Description,
+			DescriptionLocalizations: c.DescriptionLocalizations,
+			Options:                  c.Options,
+		}
+	}
+
+	subcommands := make([]*discord.SubcommandOption, 0, len(c.Subcommands))
+	for _, sub := range c.Subcommands {
+		subcommands = append(subcommands, &discord.SubcommandOption{
+			OptionName:               sub.Name,
+			Description:              sub.Description,
+			DescriptionLocalizations: sub.DescriptionLocalizations,
+			Options:                  sub.Options,
+		})
+	}
+
+	return &discord.SubcommandGroupOption{
+		OptionName:               c.Name,
+		Description:              c.Description,
+		DescriptionLocalizations: c.DescriptionLocalizations,
+		Subcommands:              subcommands,
+	}
+}
+
+// registry holds every registered command and component
+var registry = struct {
+	commands   []*Command
+	names      map[string]struct{}
+	components map[string]*Component
+}{
+	names:      make(map[string]struct{}),
+	components: make(map[string]*Component),
+}
+
+// Register adds a command, commands register themselves from the init function of their file.
+// It panics on a duplicate name or custom ID, since that is a mistake in the code.
+func Register(command *Command) {
+	if _, ok := registry.names[command.Name]; ok {
+		panic(fmt.Sprintf("command %q is registered twice", command.Name))
+	}
+
+	registry.names[command.Name] = struct{}{}
+	registry.commands = append(registry.commands, command)
+
+	for id, handler := range command.Components {
+		addComponent(&Component{ID: id, Permission: command.Permission, Handler: handler})
+	}
+}
+
+// RegisterComponent adds the handler of a message component that no command owns, such as the
+// prompts shown before a ticket opens. Everyone can use it. It panics on a duplicate custom ID.
+func RegisterComponent(id string, handler ComponentHandler) {
+	addComponent(&Component{ID: id, Handler: handler})
+}
+
+// addComponent adds a component to the registry, it panics on a duplicate custom ID
+func addComponent(component *Component) {
+	if _, ok := registry.components[component.ID]; ok {
+		panic(fmt.Sprintf("component %q is registered twice", component.ID))
+	}
+
+	registry.components[component.ID] = component
+}
+
+// All gets every registered command, in the order they were registered
+func All() []*Command {
+	return registry.commands
+}
+
+// Components gets every registered message component, keyed by custom ID
+func Components() map[string]*Component {
+	return registry.components
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"discord-bot-tickets/config"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestCreateDataMemberPermissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		command Command
+		want    *discord.Permissions
+	}{
+		{name: "everyone", command: Command{Name: "open"}},
+		{name: "supporter", command: Command{Name: "reply", Permission: config.PermissionSupporter}, want: permissionsOf(discord.PermissionManageMessages)},
+		{name: "moderator", command: Command{Name: "block", Permission: config.PermissionModerator}, want: permissionsOf(discord.PermissionModerateMembers)},
+		{name: "admin", command: Command{Name: "settings", Permission: config.PermissionAdmin}, want: permissionsOf(discord.PermissionManageGuild)},
+		{name: "context menu", command: Command{Type: discord.MessageCommand, Name: "Quote in reply", Permission: config.PermissionSupporter}, want: permissionsOf(discord.PermissionManageMessages)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.command.CreateData().DefaultMemberPermissions
+
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("DefaultMemberPermissions = %d, want none", *got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Errorf("DefaultMemberPermissions = %v, want %d", got, *tt.want)
+			}
+		})
+	}
+}
+
+// permissionsOf gets a pointer to the given permissions
+func permissionsOf(permissions discord.Permissions) *discord.Permissions {
+	return &permissions
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+)
+
+// TicketConfirmComponent opens the ticket of a user who confirmed it, or asks for its
+// type first if ticket types are configured
+func TicketConfirmComponent(ctx context.Context, service *services.BotService, data cmdroute.ComponentData) *api.InteractionResponse {
+	author := data.Event.Sender()
+	if author == nil {
+		return promptResponse(language.GetTranslation("tickets.confirm.expired"), nil)
+	}
+
+	if len(service.Config().TicketTypes) > 0 {
+		if !tickets.RefreshPendingMessages(author.ID) {
+			return promptResponse(language.GetTranslation("tickets.confirm.expired"), nil)
+		}
+
+		return promptResponse(language.GetTranslation("tickets.types.prompt"), tickets.TicketTypeMenu(service.Config()))
+	}
+
+	held := tickets.TakePendingMessages(author.ID)
+	if len(held) == 0 {
+		return promptResponse(language.GetTranslation("tickets.confirm.expired"), nil)
+	}
+
+	openPendingTicket(service, *author, held, nil)
+
+	return promptResponse(language.GetTranslation("tickets.confirm.opening"), nil)
+}
+
+// TicketCancelComponent drops the messages of a user who decided not to open a ticket
+func TicketCancelComponent(ctx context.Context, service *services.BotService, data cmdroute.ComponentData) *api.InteractionResponse {
+	if author := data.Event.Sender(); author != nil {
+		tickets.ForgetPendingMessages(author.ID)
+	}
+
+	return promptResponse(language.GetTranslation("tickets.confirm.cancelled"), nil)
+}
+
+func init() {
+	RegisterComponent(tickets.TicketConfirmComponentID, TicketConfirmComponent)
+	RegisterComponent(tickets.TicketCancelComponentID, TicketCancelComponent)
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
+	logger "discord-bot-tickets/logging"
+	"errors"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func ContactCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	message := data.Options.Find("message").String()
+
+	userID, err := data.Options.Find("user").SnowflakeValue()
+	if err != nil || message == "" {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("general.errors.no_message")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	user, ok := data.Data.Resolved.Users[discord.UserID(userID)]
+	if !ok {
+		fetched, err := service.State().User(discord.UserID(userID))
+		if err != nil {
+			logger.Error(err.Error())
+			return &api.InteractionResponseData{
+				Content: option.NewNullableString(language.GetTranslation("general.errors.generic")),
+				Flags:   discord.EphemeralMessage,
+			}
+		}
+		user = *fetched
+	}
+
+	if user.Bot {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.contact.bot")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	existing, err := tickets.GetActiveTicket(service.Config(), service.State(), service.Store(), &user)
+	if err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.contact.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	if existing != nil {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.contact.exists"), user.Mention(), existing.Channel.Mention())),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	ticket, err := tickets.ContactUser(service.Config(), service.State(), service.Store(), user, data.Event.Member.User, message)
+	if err != nil {
+		logger.Error(err.Error())
+
+		if errors.Is(err, tickets.ErrNotDelivered) {
+			return &api.InteractionResponseData{
+				Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.contact.not_delivered"), ticket.Channel.Mention())),
+				Flags:   discord.EphemeralMessage,
+			}
+		}
+
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.contact.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.contact.success"), ticket.Channel.Mention())),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func init() {
+	Register(&Command{
+		Name:        "contact",
+		Description: "Open a ticket with a member and send them a message",
+		Permission:  config.PermissionSupporter,
+		Deferred:    true,
+		Handler:     ContactCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.UserOption{
+				OptionName:  "user",
+				Description: "The member to contact",
+				Required:    true,
+			},
+			&discord.StringOption{
+				OptionName:  "message",
+				Description: "The opening message sent to the member",
+				Required:    true,
+				MaxLength:   option.NewInt(maxEditLength),
+			},
+		},
+	})
+}
@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
+	logger "discord-bot-tickets/logging"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// ReplyWithMessageCommand relays a message a staff member drafted in the ticket channel to the
+// ticket owner, as if it was sent with /reply
+func ReplyWithMessageCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	message, response := targetMessage(data)
+	if response != nil {
+		return response
+	}
+
+	staff := data.Event.Member.User
+	if message.Author.ID != staff.ID || (message.Content == "" && len(message.Attachments) == 0) {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.context.not_relayable")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	ticketOwner, response := replyRecipient(ctx, data)
+	if response != nil {
+		return response
+	}
+
+	return sendReply(service, ticketOwner, tickets.SlashCommandMessage{
+		Message:     message.Content,
+		Author:      staff,
+		Attachments: message.Attachments,
+	})
+}
+
+// QuoteInReplyCommand quotes a message of the ticket in the next reply of the staff member
+func QuoteInReplyCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	message, response := targetMessage(data)
+	if response != nil {
+		return response
+	}
+
+	text := tickets.QuotedContent(*message)
+	if text == "" {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.context.nothing_to_quote")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	tickets.SetQuote(tickets.FromContext(ctx).Author.ID, data.Event.Member.User.ID, text)
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation("commands.context.quoted")),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+// OpenTicketFromMessageCommand opens a ticket with the author of a message in a server channel,
+// with the message as the start of the ticket
+func OpenTicketFromMessageCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	message, response := targetMessage(data)
+	if response != nil {
+		return response
+	}
+
+	if message.Author.Bot {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.context.bot")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	block, err := service.Store().Blocks().FindActive(message.Author.ID, time.Now())
+	if err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("general.errors.generic")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	if block != nil {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.context.blocked"), message.Author.Mention())),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	channelTicket, err := tickets.FindChannelTicket(service.Config(), service.State(), service.Store(), message.ChannelID)
+	if err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("general.errors.generic")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	if channelTicket != nil {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.context.in_ticket")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	existing, err := tickets.GetActiveTicket(service.Config(), service.State(), service.Store(), &message.Author)
+	if err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.contact.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	if existing != nil {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.contact.exists"), message.Author.Mention(), existing.Channel.Mention())),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	ticket, err := tickets.OpenTicketFromMessage(service.Config(), service.State(), service.Store(), *message, data.Event.Member.User)
+	if err != nil {
+		logger.Error(err.Error())
+
+		if errors.Is(err, tickets.ErrNotDelivered) {
+			return &api.InteractionResponseData{
+				Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.contact.not_delivered"), ticket.Channel.Mention())),
+				Flags:   discord.EphemeralMessage,
+			}
+		}
+
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.contact.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.contact.success"), ticket.Channel.Mention())),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+// targetMessage gets the message a context menu command was used on. Discord leaves out
+// the guild of resolved messages, so it is filled in from the interaction.
+//
+// Returns: the message, or a response to send instead if it is missing
+func targetMessage(data cmdroute.CommandData) (*discord.Message, *api.InteractionResponseData) {
+	message, ok := data.Data.Resolved.Messages[data.Data.TargetMessageID()]
+	if !ok {
+		return nil, &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.context.not_found")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	message.GuildID = data.Event.GuildID
+
+	return &message, nil
+}
+
+func init() {
+	Register(&Command{
+		Type:       discord.MessageCommand,
+		Name:       "Reply to user with this",
+		Permission: config.PermissionSupporter,
+		InTicket:   true,
+		Deferred:   true,
+		Handler:    ReplyWithMessageCommand,
+	})
+
+	Register(&Command{
+		Type:       discord.MessageCommand,
+		Name:       "Quote in reply",
+		Permission: config.PermissionSupporter,
+		InTicket:   true,
+		Handler:    QuoteInReplyCommand,
+	})
+
+	Register(&Command{
+		Type:       discord.MessageCommand,
+		Name:       "Open ticket from this message",
+		Permission: config.PermissionModerator,
+		Deferred:   true,
+		Handler:    OpenTicketFromMessageCommand,
+	})
+}
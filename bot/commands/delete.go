@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
+	logger "discord-bot-tickets/logging"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func DeleteCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	entry, response := findOwnReply(ctx, service, data)
+	if response != nil {
+		return response
+	}
+
+	if err := tickets.DeleteReply(service.State(), service.Store(), entry); err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.delete.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation("commands.delete.success")),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func init() {
+	Register(&Command{
+		Name:        "delete",
+		Description: "Delete one of your replies in this ticket",
+		Permission:  config.PermissionSupporter,
+		InTicket:    true,
+		Handler:     DeleteCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.StringOption{
+				OptionName:  "message",
+				Description: "The ID or link of the reply in this channel, defaults to your latest reply",
+			},
+		},
+	})
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/config"
+	logger "discord-bot-tickets/logging"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func DutyOnCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	started, err := service.Store().Duties().Start(data.Event.Member.User.ID, time.Now())
+	if err != nil {
+		return dutyError(err)
+	}
+
+	key := "commands.duty.started"
+	if !started {
+		key = "commands.duty.already_on"
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation(key)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func DutyOffCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	stopped, err := service.Store().Duties().Stop(data.Event.Member.User.ID)
+	if err != nil {
+		return dutyError(err)
+	}
+
+	key := "commands.duty.stopped"
+	if !stopped {
+		key = "commands.duty.already_off"
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation(key)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+// dutyError logs a storage error and responds with a generic error
+func dutyError(err error) *api.InteractionResponseData {
+	logger.Error(err.Error())
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation("commands.duty.error")),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func init() {
+	Register(&Command{
+		Name:        "duty",
+		Description: "Join or leave the rotation that new tickets are assigned from",
+		Permission:  config.PermissionSupporter,
+		Subcommands: []*Command{
+			{
+				Name:        "on",
+				Description: "Start receiving new tickets",
+				Handler:     DutyOnCommand,
+			},
+			{
+				Name:        "off",
+				Description: "Stop receiving new tickets, tickets you already have stay yours",
+				Handler:     DutyOffCommand,
+			},
+		},
+	})
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// maxEditLength keeps an edited reply within a single embed description
+const maxEditLength = 4000
+
+func EditCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	content := data.Options.Find("content").String()
+	if content == "" {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("general.errors.no_message")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	entry, response := findOwnReply(ctx, service, data)
+	if response != nil {
+		return response
+	}
+
+	if err := tickets.EditReply(service.State(), service.Store(), entry, content); err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.edit.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation("commands.edit.success")),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+// findOwnReply finds the staff reply an edit or delete command refers to. That is the
+// reply given by the message option, or otherwise the latest reply of the staff member.
+//
+// Returns: the reply, or a response to send instead if it can't be used
+func findOwnReply(ctx context.Context, service *services.BotService, data cmdroute.CommandData) (*database.TicketMessage, *api.InteractionResponseData) {
+	record := tickets.FromContext(ctx).Record
+	staffID := data.Event.Member.User.ID
+
+	var (
+		entry *database.TicketMessage
+		err   error
+	)
+	if reference := data.Options.Find("message").String(); reference != "" {
+		// Accept both a message ID and a message link, which ends in the message ID
+		id, parseErr := discord.ParseSnowflake(reference[strings.LastIndex(reference, "/")+1:])
+		if parseErr != nil {
+			return nil, &api.InteractionResponseData{
+				Content: option.NewNullableString(language.GetTranslation("commands.replies.not_found")),
+				Flags:   discord.EphemeralMessage,
+			}
+		}
+		entry, err = service.Store().Messages().FindByMessageID(discord.MessageID(id))
+	} else {
+		entry, err = service.Store().Messages().FindLatestReply(record.ID, staffID)
+	}
+
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("general.errors.generic")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	if entry == nil || entry.TicketID != record.ID || entry.Direction != database.MessageOutbound || entry.DeletedAt != nil {
+		return nil, &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.replies.not_found")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	if entry.AuthorID != staffID {
+		return nil, &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.replies.not_yours")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	return entry, nil
+}
+
+func init() {
+	Register(&Command{
+		Name:        "edit",
+		Description: "Edit one of your replies in this ticket",
+		Permission:  config.PermissionSupporter,
+		InTicket:    true,
+		Handler:     EditCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.StringOption{
+				OptionName:  "content",
+				Description: "The new content of the reply",
+				Required:    true,
+				MaxLength:   option.NewInt(maxEditLength),
+			},
+			&discord.StringOption{
+				OptionName:  "message",
+				Description: "The ID or link of the reply in this channel, defaults to your latest reply",
+			},
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package duration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parse parses a human friendly duration such as "30m", "2h", "1h30m" or "3d".
+// It accepts everything time.ParseDuration does, plus a "d" suffix for whole days.
+//
+// Returns: the parsed duration and an error if any
+func Parse(value string) (time.Duration, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+
+	var days time.Duration
+	if index := strings.Index(value, "d"); index != -1 {
+		count, err := strconv.Atoi(value[:index])
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("invalid duration: %s", value)
+		}
+		days = time.Duration(count) * 24 * time.Hour
+		value = value[index+1:]
+	}
+
+	var rest time.Duration
+	if value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			return 0, fmt.Errorf("invalid duration: %s", value)
+		}
+		rest = parsed
+	}
+
+	total := days + rest
+	if total <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+
+	return total, nil
+}
+
+// Format formats a duration as days, hours and minutes, e.g. "1d 2h 5m"
+func Format(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Minute {
+		return "<1m"
+	}
+
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+
+	return strings.Join(parts, " ")
+}
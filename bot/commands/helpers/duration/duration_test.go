@@ -0,0 +1,75 @@
+package duration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "30m", want: 30 * time.Minute},
+		{value: "2h", want: 2 * time.Hour},
+		{value: "1h30m", want: 90 * time.Minute},
+		{value: "3d", want: 72 * time.Hour},
+		{value: "1d12h", want: 36 * time.Hour},
+		{value: " 7D ", want: 168 * time.Hour},
+		{value: "45s", want: 45 * time.Second},
+		{value: "", wantErr: true},
+		{value: "0", wantErr: true},
+		{value: "0m", wantErr: true},
+		{value: "0d", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "-1d", wantErr: true},
+		{value: "1d-2h", wantErr: true},
+		{value: "-1d30h", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "1.5d", wantErr: true},
+		{value: "12", wantErr: true},
+		{value: "soon", wantErr: true},
+		{value: "1d2x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := Parse(tt.value)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Parse(%q) = %s, want an error", tt.value, got)
+				}
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("Parse(%q) = %s, %v, want %s", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: 0, want: "<1m"},
+		{duration: 29 * time.Second, want: "<1m"},
+		{duration: 30 * time.Second, want: "1m"},
+		{duration: 90 * time.Minute, want: "1h 30m"},
+		{duration: 24 * time.Hour, want: "1d"},
+		{duration: 26*time.Hour + 5*time.Minute, want: "1d 2h 5m"},
+		{duration: 72*time.Hour + 30*time.Minute, want: "3d 30m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := Format(tt.duration); got != tt.want {
+				t.Errorf("Format(%s) = %q, want %q", tt.duration, got, tt.want)
+			}
+		})
+	}
+}
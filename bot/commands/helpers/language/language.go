@@ -26,28 +26,215 @@ type LanguageFile struct {
 			Generic    Translation `json:"generic"`
 			NotATicket Translation `json:"not_a_ticket"`
 			NoMessage  Translation `json:"no_message"`
+			Permission Translation `json:"permission"`
 		} `json:"errors"`
 		Success struct {
 			Generic Translation `json:"generic"`
 		} `json:"success"`
+		Permissions struct {
+			Supporter Translation `json:"supporter"`
+			Moderator Translation `json:"moderator"`
+			Admin     Translation `json:"admin"`
+		} `json:"permissions"`
 	} `json:"general"`
 	Commands struct {
 		Close struct {
-			Success Translation `json:"success"`
-			Error   Translation `json:"error"`
+			Success          Translation `json:"success"`
+			Error            Translation `json:"error"`
+			Scheduled        Translation `json:"scheduled"`
+			Cancelled        Translation `json:"cancelled"`
+			CancelledByReply Translation `json:"cancelled_by_reply"`
+			NotScheduled     Translation `json:"not_scheduled"`
+			InvalidDuration  Translation `json:"invalid_duration"`
 		} `json:"close"`
 		Reply struct {
+			Success      Translation `json:"success"`
+			Error        Translation `json:"error"`
+			NotDelivered Translation `json:"not_delivered"`
+			Claimed      Translation `json:"claimed"`
+		} `json:"reply"`
+		Transcript struct {
+			NotFound Translation `json:"not_found"`
+			Error    Translation `json:"error"`
+		} `json:"transcript"`
+		Edit struct {
 			Success Translation `json:"success"`
 			Error   Translation `json:"error"`
-		} `json:"reply"`
+		} `json:"edit"`
+		Delete struct {
+			Success Translation `json:"success"`
+			Error   Translation `json:"error"`
+		} `json:"delete"`
+		Replies struct {
+			NotFound Translation `json:"not_found"`
+			NotYours Translation `json:"not_yours"`
+		} `json:"replies"`
+		Note struct {
+			Success Translation `json:"success"`
+			Error   Translation `json:"error"`
+		} `json:"note"`
+		Snippet struct {
+			Added     Translation `json:"added"`
+			Updated   Translation `json:"updated"`
+			Removed   Translation `json:"removed"`
+			Exists    Translation `json:"exists"`
+			NotFound  Translation `json:"not_found"`
+			Invalid   Translation `json:"invalid"`
+			Empty     Translation `json:"empty"`
+			ListTitle Translation `json:"list_title"`
+			Error     Translation `json:"error"`
+		} `json:"snippet"`
+		Contact struct {
+			Success      Translation `json:"success"`
+			Exists       Translation `json:"exists"`
+			NotDelivered Translation `json:"not_delivered"`
+			Bot          Translation `json:"bot"`
+			Error        Translation `json:"error"`
+		} `json:"contact"`
+		Block struct {
+			Success         Translation `json:"success"`
+			SuccessUntil    Translation `json:"success_until"`
+			Bot             Translation `json:"bot"`
+			InvalidDuration Translation `json:"invalid_duration"`
+			NotBlocked      Translation `json:"not_blocked"`
+			Unblocked       Translation `json:"unblocked"`
+			Empty           Translation `json:"empty"`
+			ListTitle       Translation `json:"list_title"`
+			Permanent       Translation `json:"permanent"`
+			More            Translation `json:"more"`
+			Error           Translation `json:"error"`
+		} `json:"block"`
+		Claim struct {
+			Claimed      Translation `json:"claimed"`
+			Unclaimed    Translation `json:"unclaimed"`
+			Assigned     Translation `json:"assigned"`
+			AlreadyYours Translation `json:"already_yours"`
+			Taken        Translation `json:"taken"`
+			NotClaimed   Translation `json:"not_claimed"`
+			Bot          Translation `json:"bot"`
+			Error        Translation `json:"error"`
+			NotStaff     Translation `json:"not_staff"`
+		} `json:"claim"`
+		Duty struct {
+			Started    Translation `json:"started"`
+			AlreadyOn  Translation `json:"already_on"`
+			Stopped    Translation `json:"stopped"`
+			AlreadyOff Translation `json:"already_off"`
+			Error      Translation `json:"error"`
+		} `json:"duty"`
+		Priority struct {
+			Changed   Translation `json:"changed"`
+			Unchanged Translation `json:"unchanged"`
+			Error     Translation `json:"error"`
+		} `json:"priority"`
+		Tag struct {
+			Added         Translation `json:"added"`
+			Removed       Translation `json:"removed"`
+			AlreadyTagged Translation `json:"already_tagged"`
+			NotTagged     Translation `json:"not_tagged"`
+			Invalid       Translation `json:"invalid"`
+			Empty         Translation `json:"empty"`
+			ListTitle     Translation `json:"list_title"`
+			Count         Translation `json:"count"`
+			SearchTitle   Translation `json:"search_title"`
+			NoResults     Translation `json:"no_results"`
+			Error         Translation `json:"error"`
+		} `json:"tag"`
+		Context struct {
+			NotRelayable   Translation `json:"not_relayable"`
+			NothingToQuote Translation `json:"nothing_to_quote"`
+			Quoted         Translation `json:"quoted"`
+			NotFound       Translation `json:"not_found"`
+			Bot            Translation `json:"bot"`
+			InTicket       Translation `json:"in_ticket"`
+			Blocked        Translation `json:"blocked"`
+		} `json:"context"`
 	} `json:"commands"`
 	Embeds struct {
 		TicketClosed struct {
 			Title       Translation `json:"title"`
 			Description Translation `json:"description"`
 			Footer      Translation `json:"footer"`
+			Reason      Translation `json:"reason"`
 		} `json:"ticket_closed"`
+		CloseScheduled struct {
+			Title       Translation `json:"title"`
+			Description Translation `json:"description"`
+		} `json:"close_scheduled"`
+		TicketSummary struct {
+			Title         Translation `json:"title"`
+			OpenedBy      Translation `json:"opened_by"`
+			ClosedBy      Translation `json:"closed_by"`
+			UnknownCloser Translation `json:"unknown_closer"`
+			Duration      Translation `json:"duration"`
+			Messages      Translation `json:"messages"`
+			MessageCounts Translation `json:"message_counts"`
+			Reason        Translation `json:"reason"`
+			NoReason      Translation `json:"no_reason"`
+			ClaimedBy     Translation `json:"claimed_by"`
+			Unclaimed     Translation `json:"unclaimed"`
+			Priority      Translation `json:"priority"`
+			Tags          Translation `json:"tags"`
+			NoTags        Translation `json:"no_tags"`
+		} `json:"ticket_summary"`
+		InactivityWarning struct {
+			Title       Translation `json:"title"`
+			Description Translation `json:"description"`
+		} `json:"inactivity_warning"`
+		Relay struct {
+			Attachments Translation `json:"attachments"`
+			Stickers    Translation `json:"stickers"`
+			Original    Translation `json:"original"`
+			Edited      Translation `json:"edited"`
+			Deleted     Translation `json:"deleted"`
+			Anonymous   Translation `json:"anonymous"`
+			StaffTeam   Translation `json:"staff_team"`
+		} `json:"relay"`
+		Note struct {
+			Title  Translation `json:"title"`
+			Footer Translation `json:"footer"`
+		} `json:"note"`
+		Contact struct {
+			Description Translation `json:"description"`
+			FromMessage Translation `json:"from_message"`
+		} `json:"contact"`
 	} `json:"embeds"`
+	Tickets struct {
+		Inactivity struct {
+			Warned Translation `json:"warned"`
+			Reason Translation `json:"reason"`
+		} `json:"inactivity"`
+		Relay struct {
+			Failed Translation `json:"failed"`
+		} `json:"relay"`
+		Blocked struct {
+			Permanent Translation `json:"permanent"`
+			Temporary Translation `json:"temporary"`
+		} `json:"blocked"`
+		Claim struct {
+			Topic Translation `json:"topic"`
+		} `json:"claim"`
+		Assignment struct {
+			Assigned  Translation `json:"assigned"`
+			NewTicket Translation `json:"new_ticket"`
+		} `json:"assignment"`
+		Types struct {
+			Prompt      Translation `json:"prompt"`
+			Placeholder Translation `json:"placeholder"`
+			Chosen      Translation `json:"chosen"`
+		} `json:"types"`
+		Confirm struct {
+			Prompt    Translation `json:"prompt"`
+			Confirm   Translation `json:"confirm"`
+			Cancel    Translation `json:"cancel"`
+			Opening   Translation `json:"opening"`
+			Cancelled Translation `json:"cancelled"`
+			Expired   Translation `json:"expired"`
+		} `json:"confirm"`
+		Contact struct {
+			FromMessage Translation `json:"from_message"`
+		} `json:"contact"`
+	} `json:"tickets"`
 }
 
 var (
@@ -157,12 +344,23 @@ func GetTranslation(key string) string {
 				translation = translations[selectedLang].General.Errors.NotATicket
 			case "no_message":
 				translation = translations[selectedLang].General.Errors.NoMessage
+			case "permission":
+				translation = translations[selectedLang].General.Errors.Permission
 			}
 		case "success":
 			switch parts[2] {
 			case "generic":
 				translation = translations[selectedLang].General.Success.Generic
 			}
+		case "permissions":
+			switch parts[2] {
+			case "supporter":
+				translation = translations[selectedLang].General.Permissions.Supporter
+			case "moderator":
+				translation = translations[selectedLang].General.Permissions.Moderator
+			case "admin":
+				translation = translations[selectedLang].General.Permissions.Admin
+			}
 		}
 	case "commands":
 		switch parts[1] {
@@ -172,6 +370,16 @@ func GetTranslation(key string) string {
 				translation = translations[selectedLang].Commands.Close.Success
 			case "error":
 				translation = translations[selectedLang].Commands.Close.Error
+			case "scheduled":
+				translation = translations[selectedLang].Commands.Close.Scheduled
+			case "cancelled":
+				translation = translations[selectedLang].Commands.Close.Cancelled
+			case "cancelled_by_reply":
+				translation = translations[selectedLang].Commands.Close.CancelledByReply
+			case "not_scheduled":
+				translation = translations[selectedLang].Commands.Close.NotScheduled
+			case "invalid_duration":
+				translation = translations[selectedLang].Commands.Close.InvalidDuration
 			}
 		case "reply":
 			switch parts[2] {
@@ -179,6 +387,189 @@ func GetTranslation(key string) string {
 				translation = translations[selectedLang].Commands.Reply.Success
 			case "error":
 				translation = translations[selectedLang].Commands.Reply.Error
+			case "not_delivered":
+				translation = translations[selectedLang].Commands.Reply.NotDelivered
+			case "claimed":
+				translation = translations[selectedLang].Commands.Reply.Claimed
+			}
+		case "transcript":
+			switch parts[2] {
+			case "not_found":
+				translation = translations[selectedLang].Commands.Transcript.NotFound
+			case "error":
+				translation = translations[selectedLang].Commands.Transcript.Error
+			}
+		case "edit":
+			switch parts[2] {
+			case "success":
+				translation = translations[selectedLang].Commands.Edit.Success
+			case "error":
+				translation = translations[selectedLang].Commands.Edit.Error
+			}
+		case "delete":
+			switch parts[2] {
+			case "success":
+				translation = translations[selectedLang].Commands.Delete.Success
+			case "error":
+				translation = translations[selectedLang].Commands.Delete.Error
+			}
+		case "replies":
+			switch parts[2] {
+			case "not_found":
+				translation = translations[selectedLang].Commands.Replies.NotFound
+			case "not_yours":
+				translation = translations[selectedLang].Commands.Replies.NotYours
+			}
+		case "note":
+			switch parts[2] {
+			case "success":
+				translation = translations[selectedLang].Commands.Note.Success
+			case "error":
+				translation = translations[selectedLang].Commands.Note.Error
+			}
+		case "snippet":
+			switch parts[2] {
+			case "added":
+				translation = translations[selectedLang].Commands.Snippet.Added
+			case "updated":
+				translation = translations[selectedLang].Commands.Snippet.Updated
+			case "removed":
+				translation = translations[selectedLang].Commands.Snippet.Removed
+			case "exists":
+				translation = translations[selectedLang].Commands.Snippet.Exists
+			case "not_found":
+				translation = translations[selectedLang].Commands.Snippet.NotFound
+			case "invalid":
+				translation = translations[selectedLang].Commands.Snippet.Invalid
+			case "empty":
+				translation = translations[selectedLang].Commands.Snippet.Empty
+			case "list_title":
+				translation = translations[selectedLang].Commands.Snippet.ListTitle
+			case "error":
+				translation = translations[selectedLang].Commands.Snippet.Error
+			}
+		case "contact":
+			switch parts[2] {
+			case "success":
+				translation = translations[selectedLang].Commands.Contact.Success
+			case "exists":
+				translation = translations[selectedLang].Commands.Contact.Exists
+			case "not_delivered":
+				translation = translations[selectedLang].Commands.Contact.NotDelivered
+			case "bot":
+				translation = translations[selectedLang].Commands.Contact.Bot
+			case "error":
+				translation = translations[selectedLang].Commands.Contact.Error
+			}
+		case "block":
+			switch parts[2] {
+			case "success":
+				translation = translations[selectedLang].Commands.Block.Success
+			case "success_until":
+				translation = translations[selectedLang].Commands.Block.SuccessUntil
+			case "bot":
+				translation = translations[selectedLang].Commands.Block.Bot
+			case "invalid_duration":
+				translation = translations[selectedLang].Commands.Block.InvalidDuration
+			case "not_blocked":
+				translation = translations[selectedLang].Commands.Block.NotBlocked
+			case "unblocked":
+				translation = translations[selectedLang].Commands.Block.Unblocked
+			case "empty":
+				translation = translations[selectedLang].Commands.Block.Empty
+			case "list_title":
+				translation = translations[selectedLang].Commands.Block.ListTitle
+			case "permanent":
+				translation = translations[selectedLang].Commands.Block.Permanent
+			case "more":
+				translation = translations[selectedLang].Commands.Block.More
+			case "error":
+				translation = translations[selectedLang].Commands.Block.Error
+			}
+		case "claim":
+			switch parts[2] {
+			case "claimed":
+				translation = translations[selectedLang].Commands.Claim.Claimed
+			case "unclaimed":
+				translation = translations[selectedLang].Commands.Claim.Unclaimed
+			case "assigned":
+				translation = translations[selectedLang].Commands.Claim.Assigned
+			case "already_yours":
+				translation = translations[selectedLang].Commands.Claim.AlreadyYours
+			case "taken":
+				translation = translations[selectedLang].Commands.Claim.Taken
+			case "not_claimed":
+				translation = translations[selectedLang].Commands.Claim.NotClaimed
+			case "bot":
+				translation = translations[selectedLang].Commands.Claim.Bot
+			case "error":
+				translation = translations[selectedLang].Commands.Claim.Error
+			case "not_staff":
+				translation = translations[selectedLang].Commands.Claim.NotStaff
+			}
+		case "duty":
+			switch parts[2] {
+			case "started":
+				translation = translations[selectedLang].Commands.Duty.Started
+			case "already_on":
+				translation = translations[selectedLang].Commands.Duty.AlreadyOn
+			case "stopped":
+				translation = translations[selectedLang].Commands.Duty.Stopped
+			case "already_off":
+				translation = translations[selectedLang].Commands.Duty.AlreadyOff
+			case "error":
+				translation = translations[selectedLang].Commands.Duty.Error
+			}
+		case "priority":
+			switch parts[2] {
+			case "changed":
+				translation = translations[selectedLang].Commands.Priority.Changed
+			case "unchanged":
+				translation = translations[selectedLang].Commands.Priority.Unchanged
+			case "error":
+				translation = translations[selectedLang].Commands.Priority.Error
+			}
+		case "tag":
+			switch parts[2] {
+			case "added":
+				translation = translations[selectedLang].Commands.Tag.Added
+			case "removed":
+				translation = translations[selectedLang].Commands.Tag.Removed
+			case "already_tagged":
+				translation = translations[selectedLang].Commands.Tag.AlreadyTagged
+			case "not_tagged":
+				translation = translations[selectedLang].Commands.Tag.NotTagged
+			case "invalid":
+				translation = translations[selectedLang].Commands.Tag.Invalid
+			case "empty":
+				translation = translations[selectedLang].Commands.Tag.Empty
+			case "list_title":
+				translation = translations[selectedLang].Commands.Tag.ListTitle
+			case "count":
+				translation = translations[selectedLang].Commands.Tag.Count
+			case "search_title":
+				translation = translations[selectedLang].Commands.Tag.SearchTitle
+			case "no_results":
+				translation = translations[selectedLang].Commands.Tag.NoResults
+			case "error":
+				translation = translations[selectedLang].Commands.Tag.Error
+			}
+		case "context":
+			switch parts[2] {
+			case "not_relayable":
+				translation = translations[selectedLang].Commands.Context.NotRelayable
+			case "nothing_to_quote":
+				translation = translations[selectedLang].Commands.Context.NothingToQuote
+			case "quoted":
+				translation = translations[selectedLang].Commands.Context.Quoted
+			case "not_found":
+				translation = translations[selectedLang].Commands.Context.NotFound
+			case "bot":
+				translation = translations[selectedLang].Commands.Context.Bot
+			case "in_ticket":
+				translation = translations[selectedLang].Commands.Context.InTicket
+			case "blocked":
+				translation = translations[selectedLang].Commands.Context.Blocked
 			}
 		}
 	case "embeds":
@@ -191,6 +582,147 @@ func GetTranslation(key string) string {
 				translation = translations[selectedLang].Embeds.TicketClosed.Description
 			case "footer":
 				translation = translations[selectedLang].Embeds.TicketClosed.Footer
+			case "reason":
+				translation = translations[selectedLang].Embeds.TicketClosed.Reason
+			}
+		case "close_scheduled":
+			switch parts[2] {
+			case "title":
+				translation = translations[selectedLang].Embeds.CloseScheduled.Title
+			case "description":
+				translation = translations[selectedLang].Embeds.CloseScheduled.Description
+			}
+		case "ticket_summary":
+			switch parts[2] {
+			case "title":
+				translation = translations[selectedLang].Embeds.TicketSummary.Title
+			case "opened_by":
+				translation = translations[selectedLang].Embeds.TicketSummary.OpenedBy
+			case "closed_by":
+				translation = translations[selectedLang].Embeds.TicketSummary.ClosedBy
+			case "unknown_closer":
+				translation = translations[selectedLang].Embeds.TicketSummary.UnknownCloser
+			case "duration":
+				translation = translations[selectedLang].Embeds.TicketSummary.Duration
+			case "messages":
+				translation = translations[selectedLang].Embeds.TicketSummary.Messages
+			case "message_counts":
+				translation = translations[selectedLang].Embeds.TicketSummary.MessageCounts
+			case "reason":
+				translation = translations[selectedLang].Embeds.TicketSummary.Reason
+			case "no_reason":
+				translation = translations[selectedLang].Embeds.TicketSummary.NoReason
+			case "claimed_by":
+				translation = translations[selectedLang].Embeds.TicketSummary.ClaimedBy
+			case "unclaimed":
+				translation = translations[selectedLang].Embeds.TicketSummary.Unclaimed
+			case "priority":
+				translation = translations[selectedLang].Embeds.TicketSummary.Priority
+			case "tags":
+				translation = translations[selectedLang].Embeds.TicketSummary.Tags
+			case "no_tags":
+				translation = translations[selectedLang].Embeds.TicketSummary.NoTags
+			}
+		case "inactivity_warning":
+			switch parts[2] {
+			case "title":
+				translation = translations[selectedLang].Embeds.InactivityWarning.Title
+			case "description":
+				translation = translations[selectedLang].Embeds.InactivityWarning.Description
+			}
+		case "relay":
+			switch parts[2] {
+			case "attachments":
+				translation = translations[selectedLang].Embeds.Relay.Attachments
+			case "stickers":
+				translation = translations[selectedLang].Embeds.Relay.Stickers
+			case "original":
+				translation = translations[selectedLang].Embeds.Relay.Original
+			case "edited":
+				translation = translations[selectedLang].Embeds.Relay.Edited
+			case "deleted":
+				translation = translations[selectedLang].Embeds.Relay.Deleted
+			case "anonymous":
+				translation = translations[selectedLang].Embeds.Relay.Anonymous
+			case "staff_team":
+				translation = translations[selectedLang].Embeds.Relay.StaffTeam
+			}
+		case "note":
+			switch parts[2] {
+			case "title":
+				translation = translations[selectedLang].Embeds.Note.Title
+			case "footer":
+				translation = translations[selectedLang].Embeds.Note.Footer
+			}
+		case "contact":
+			switch parts[2] {
+			case "description":
+				translation = translations[selectedLang].Embeds.Contact.Description
+			case "from_message":
+				translation = translations[selectedLang].Embeds.Contact.FromMessage
+			}
+		}
+	case "tickets":
+		switch parts[1] {
+		case "inactivity":
+			switch parts[2] {
+			case "warned":
+				translation = translations[selectedLang].Tickets.Inactivity.Warned
+			case "reason":
+				translation = translations[selectedLang].Tickets.Inactivity.Reason
+			}
+		case "relay":
+			switch parts[2] {
+			case "failed":
+				translation = translations[selectedLang].Tickets.Relay.Failed
+			}
+		case "blocked":
+			switch parts[2] {
+			case "permanent":
+				translation = translations[selectedLang].Tickets.Blocked.Permanent
+			case "temporary":
+				translation = translations[selectedLang].Tickets.Blocked.Temporary
+			}
+		case "claim":
+			switch parts[2] {
+			case "topic":
+				translation = translations[selectedLang].Tickets.Claim.Topic
+			}
+		case "assignment":
+			switch parts[2] {
+			case "assigned":
+				translation = translations[selectedLang].Tickets.Assignment.Assigned
+			case "new_ticket":
+				translation = translations[selectedLang].Tickets.Assignment.NewTicket
+			}
+		case "types":
+			switch parts[2] {
+			case "prompt":
+				translation = translations[selectedLang].Tickets.Types.Prompt
+			case "placeholder":
+				translation = translations[selectedLang].Tickets.Types.Placeholder
+			case "chosen":
+				translation = translations[selectedLang].Tickets.Types.Chosen
+			}
+		case "confirm":
+			switch parts[2] {
+			case "prompt":
+				translation = translations[selectedLang].Tickets.Confirm.Prompt
+			case "confirm":
+				translation = translations[selectedLang].Tickets.Confirm.Confirm
+			case "cancel":
+				translation = translations[selectedLang].Tickets.Confirm.Cancel
+			case "opening":
+				translation = translations[selectedLang].Tickets.Confirm.Opening
+			case "cancelled":
+				translation = translations[selectedLang].Tickets.Confirm.Cancelled
+			case "expired":
+				translation = translations[selectedLang].Tickets.Confirm.Expired
+			}
+		case "contact":
+			switch parts[2] {
+			case "from_message":
+				translation = translations[selectedLang].Tickets.Contact.FromMessage
 			}
 		}
 	}
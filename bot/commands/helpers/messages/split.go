@@ -0,0 +1,40 @@
+package messages
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Split splits content into chunks of at most limit characters. Chunks are cut
+// at the last line break, or failing that the last space, that still fits.
+//
+// Returns: a slice of chunks, empty if the content is empty
+func Split(content string, limit int) []string {
+	var chunks []string
+
+	for utf8.RuneCountInString(content) > limit {
+		// Byte offset of the first rune that no longer fits
+		end := 0
+		for i := 0; i < limit; i++ {
+			_, size := utf8.DecodeRuneInString(content[end:])
+			end += size
+		}
+
+		cut := strings.LastIndex(content[:end], "\n")
+		if cut <= 0 {
+			cut = strings.LastIndex(content[:end], " ")
+		}
+		if cut <= 0 {
+			cut = end
+		}
+
+		chunks = append(chunks, content[:cut])
+		content = strings.TrimLeft(content[cut:], "\n ")
+	}
+
+	if content != "" {
+		chunks = append(chunks, content)
+	}
+
+	return chunks
+}
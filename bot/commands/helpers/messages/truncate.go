@@ -0,0 +1,21 @@
+package messages
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TruncateLines joins as many lines as fit within limit characters, dropping the rest
+//
+// Returns: the lines joined with line breaks
+func TruncateLines(lines []string, limit int) string {
+	length := 0
+	for i, line := range lines {
+		length += utf8.RuneCountInString(line) + 1
+		if length > limit {
+			return strings.Join(lines[:i], "\n")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,48 @@
+package permissions
+
+import (
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/config"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// Allowed checks if a member has at least the required permission level.
+// Outside of a guild there is no member, and without any roles configured nobody
+// has a level, so in both cases only PermissionEveryone is allowed.
+func Allowed(cfg *config.Config, member *discord.Member, required config.PermissionLevel) bool {
+	if required <= config.PermissionEveryone {
+		return true
+	}
+
+	if member == nil {
+		return false
+	}
+
+	return cfg.Permissions.Level(member.RoleIDs) >= required
+}
+
+// Denied is the response to a member without the required permission level
+//
+// Returns: a pointer to the InteractionResponseData
+func Denied(required config.PermissionLevel) *api.InteractionResponseData {
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("general.errors.permission"), levelName(required))),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+// levelName gets the translated name of a permission level
+func levelName(level config.PermissionLevel) string {
+	switch level {
+	case config.PermissionSupporter:
+		return language.GetTranslation("general.permissions.supporter")
+	case config.PermissionModerator:
+		return language.GetTranslation("general.permissions.moderator")
+	default:
+		return language.GetTranslation("general.permissions.admin")
+	}
+}
@@ -0,0 +1,47 @@
+package permissions
+
+import (
+	"discord-bot-tickets/config"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestAllowed(t *testing.T) {
+	const (
+		supporterRole discord.RoleID = 1
+		adminRole     discord.RoleID = 3
+	)
+
+	configured := &config.Config{Permissions: config.PermissionsConfig{Roles: map[config.PermissionLevel][]discord.RoleID{
+		config.PermissionSupporter: {supporterRole},
+		config.PermissionAdmin:     {adminRole},
+	}}}
+	unconfigured := &config.Config{}
+
+	tests := []struct {
+		name     string
+		cfg      *config.Config
+		member   *discord.Member
+		required config.PermissionLevel
+		want     bool
+	}{
+		{name: "everyone without a member", cfg: configured, required: config.PermissionEveryone, want: true},
+		{name: "staff command without a member", cfg: configured, required: config.PermissionSupporter, want: false},
+		{name: "everyone without roles configured", cfg: unconfigured, member: &discord.Member{}, required: config.PermissionEveryone, want: true},
+		{name: "staff command without roles configured", cfg: unconfigured, member: &discord.Member{RoleIDs: []discord.RoleID{adminRole}}, required: config.PermissionSupporter, want: false},
+		{name: "staff command for a regular member", cfg: configured, member: &discord.Member{}, required: config.PermissionSupporter, want: false},
+		{name: "staff command for a staff member", cfg: configured, member: &discord.Member{RoleIDs: []discord.RoleID{supporterRole}}, required: config.PermissionSupporter, want: true},
+		{name: "moderator command for a staff member", cfg: configured, member: &discord.Member{RoleIDs: []discord.RoleID{supporterRole}}, required: config.PermissionModerator, want: false},
+		{name: "unconfigured level for an admin", cfg: configured, member: &discord.Member{RoleIDs: []discord.RoleID{adminRole}}, required: config.PermissionModerator, want: true},
+		{name: "admin command for an admin", cfg: configured, member: &discord.Member{RoleIDs: []discord.RoleID{adminRole}}, required: config.PermissionAdmin, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allowed(tt.cfg, tt.member, tt.required); got != tt.want {
+				t.Errorf("Allowed(%s) = %v, want %v", tt.required, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
+	logger "discord-bot-tickets/logging"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// maxNoteLength keeps a note within a single embed description
+const maxNoteLength = 4000
+
+func NoteCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	content := data.Options.Find("content").String()
+	if content == "" {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("general.errors.no_message")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	record := tickets.FromContext(ctx).Record
+
+	if _, err := tickets.AddNote(service.State(), service.Store(), record, data.Event.Member.User, content); err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.note.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation("commands.note.success")),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func init() {
+	Register(&Command{
+		Name:        "note",
+		Description: "Add an internal note to this ticket, it is never sent to the user",
+		Permission:  config.PermissionSupporter,
+		InTicket:    true,
+		Handler:     NoteCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.StringOption{
+				OptionName:  "content",
+				Description: "The content of the note",
+				Required:    true,
+				MaxLength:   option.NewInt(maxNoteLength),
+			},
+		},
+	})
+}
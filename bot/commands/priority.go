@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func PriorityCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	priority := database.TicketPriority(data.Options.Find("level").String())
+
+	record := tickets.FromContext(ctx).Record
+
+	if record.Priority == priority {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.priority.unchanged"), priority)),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	if err := tickets.SetPriority(service.State(), service.Store(), record, priority); err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.priority.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	// Not ephemeral, so the rest of the staff can see the change
+	return &api.InteractionResponseData{
+		Embeds: &[]discord.Embed{{
+			Description: fmt.Sprintf(language.GetTranslation("commands.priority.changed"), data.Event.Member.User.Mention(), priority),
+			Color:       tickets.PriorityColor(priority),
+		}},
+	}
+}
+
+func init() {
+	choices := make([]discord.StringChoice, 0, len(database.Priorities))
+	for _, priority := range database.Priorities {
+		choices = append(choices, discord.StringChoice{Name: string(priority), Value: string(priority)})
+	}
+
+	Register(&Command{
+		Name:        "priority",
+		Description: "Change the priority of this ticket",
+		Permission:  config.PermissionSupporter,
+		InTicket:    true,
+		Handler:     PriorityCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.StringOption{
+				OptionName:  "level",
+				Description: "The new priority",
+				Required:    true,
+				Choices:     choices,
+			},
+		},
+	})
+}
@@ -2,9 +2,13 @@ package commands
 
 import (
 	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
 	"discord-bot-tickets/bot/services"
 	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
 	logger "discord-bot-tickets/logging"
+	"errors"
+	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/api/cmdroute"
@@ -13,80 +17,111 @@ import (
 )
 
 func ReplyCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
-	options := data.Options
-	if len(options) == 0 {
-		return &api.InteractionResponseData{
-			Content: option.NewNullableString("Please provide a message to send."),
-			Flags:   discord.EphemeralMessage,
-		}
-	}
+	message := data.Options.Find("message").String()
 
-	message := options[0].String()
-	if message == "" {
-		return &api.InteractionResponseData{
-			Content: option.NewNullableString("Please provide a message to send."),
-			Flags:   discord.EphemeralMessage,
+	anonymous, _ := data.Options.Find("anonymous").BoolValue()
+
+	var attachments []discord.Attachment
+	if id, err := data.Options.Find("attachment").SnowflakeValue(); err == nil && id.IsValid() {
+		if attachment, ok := data.Data.Resolved.Attachments[discord.AttachmentID(id)]; ok {
+			attachments = append(attachments, attachment)
 		}
 	}
 
-	// Get the channel where the command was used
-	channel, err := service.State().Channel(data.Event.ChannelID)
-	if err != nil {
+	if message == "" && len(attachments) == 0 {
 		return &api.InteractionResponseData{
-			Content: option.NewNullableString("Error getting channel information."),
+			Content: option.NewNullableString(language.GetTranslation("general.errors.no_message")),
 			Flags:   discord.EphemeralMessage,
 		}
 	}
 
-	// Get the ticket owner from the channel topic
-	ticketOwner, err := tickets.GetAuthorFromChannel(service.State(), channel)
-	if err != nil {
-		return &api.InteractionResponseData{
-			Content: option.NewNullableString("Error getting ticket owner information."),
-			Flags:   discord.EphemeralMessage,
-		}
+	ticketOwner, response := replyRecipient(ctx, data)
+	if response != nil {
+		return response
 	}
 
-	if ticketOwner == nil {
-		return &api.InteractionResponseData{
-			Content: option.NewNullableString("This channel is not a ticket."),
-			Flags:   discord.EphemeralMessage,
+	return sendReply(service, ticketOwner, tickets.SlashCommandMessage{
+		Message:     message,
+		Author:      data.Event.Member.User,
+		Attachments: attachments,
+		Anonymous:   anonymous,
+	})
+}
+
+// replyRecipient gets the owner of the ticket the command was used in
+//
+// Returns: the ticket owner, or a response to send instead if there is none
+func replyRecipient(ctx context.Context, data cmdroute.CommandData) (*discord.User, *api.InteractionResponseData) {
+	ticket := tickets.FromContext(ctx)
+
+	// Only the claimer replies to a claimed ticket, unless the reply is forced
+	if force, _ := data.Options.Find("force").BoolValue(); !force {
+		if claimedBy := ticket.Record.ClaimedBy; claimedBy.IsValid() && claimedBy != data.Event.Member.User.ID {
+			return nil, &api.InteractionResponseData{
+				Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.reply.claimed"), claimedBy.Mention())),
+				Flags:   discord.EphemeralMessage,
+			}
 		}
 	}
 
+	return ticket.Author, nil
+}
+
+// sendReply relays a staff reply to the owner of a ticket, quoting the message the staff member chose if any
+func sendReply(service *services.BotService, ticketOwner *discord.User, message tickets.SlashCommandMessage) *api.InteractionResponseData {
+	message.Message = tickets.WithQuote(ticketOwner.ID, message.Author.ID, message.Message)
+
 	// Update the ticket with the reply
-	if err = tickets.UpdateTicket(service.Config(), service.State(), *ticketOwner, tickets.SlashCommandMessage{
-		Message: message,
-		Author:  data.Event.Member.User,
-	}); err != nil {
+	if err := tickets.UpdateTicket(service.Config(), service.State(), service.Store(), *ticketOwner, message); err != nil {
 		logger.Error(err.Error())
+
+		if errors.Is(err, tickets.ErrNotDelivered) {
+			return &api.InteractionResponseData{
+				Content: option.NewNullableString(language.GetTranslation("commands.reply.not_delivered")),
+				Flags:   discord.EphemeralMessage,
+			}
+		}
+
 		return &api.InteractionResponseData{
-			Content: option.NewNullableString("Error updating ticket."),
+			Content: option.NewNullableString(language.GetTranslation("commands.reply.error")),
 			Flags:   discord.EphemeralMessage,
 		}
 	}
 
 	return &api.InteractionResponseData{
-		Content: option.NewNullableString("Message sent successfully."),
+		Content: option.NewNullableString(language.GetTranslation("commands.reply.success")),
 		Flags:   discord.EphemeralMessage,
 	}
 }
 
-func GetReplyLocale() map[discord.Language]string {
-	return map[discord.Language]string{}
+// forceOption lets staff reply to a ticket that another staff member claimed
+var forceOption = &discord.BooleanOption{
+	OptionName:  "force",
+	Description: "Reply even though another staff member claimed this ticket",
 }
 
-func GetReplyDescription() string {
-	return "Reply to a ModMail ticket!"
-}
-
-func GetReplyOptions() discord.CommandOptions {
-	// return a command option with a string type
-	return discord.CommandOptions{
-		&discord.StringOption{
-			OptionName:  "message",
-			Description: "The message to reply with",
-			Required:    true,
+func init() {
+	Register(&Command{
+		Name:        "reply",
+		Description: "Reply to a ModMail ticket!",
+		Permission:  config.PermissionSupporter,
+		InTicket:    true,
+		Deferred:    true,
+		Handler:     ReplyCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.StringOption{
+				OptionName:  "message",
+				Description: "The message to reply with",
+			},
+			&discord.AttachmentOption{
+				OptionName:  "attachment",
+				Description: "A file to send along with the reply",
+			},
+			&discord.BooleanOption{
+				OptionName:  "anonymous",
+				Description: "Hide your name from the user, staff still see who replied",
+			},
+			forceOption,
 		},
-	}
+	})
 }
@@ -0,0 +1,335 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/colors"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/commands/helpers/messages"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// maxSnippetNameLength is the longest snippet name that is accepted
+const maxSnippetNameLength = 100
+
+// maxSnippetListLength keeps the snippet list within a single embed description
+const maxSnippetListLength = 4000
+
+// maxAutocompleteChoices is the number of choices Discord shows for autocompletion
+const maxAutocompleteChoices = 25
+
+func SnippetAddCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	name := snippetName(data.Options.Find("name").String())
+	content := data.Options.Find("content").String()
+
+	if name == "" || content == "" {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.snippet.invalid")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	existing, err := service.Store().Snippets().FindByName(name)
+	if err != nil {
+		return snippetError(err)
+	}
+
+	if existing != nil {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.snippet.exists"), name)),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	err = service.Store().Snippets().Create(&database.Snippet{
+		Name:      name,
+		Content:   content,
+		CreatedBy: data.Event.Member.User.ID,
+	})
+	if err != nil {
+		return snippetError(err)
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.snippet.added"), name)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func SnippetEditCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	name := snippetName(data.Options.Find("name").String())
+	content := data.Options.Find("content").String()
+
+	if name == "" || content == "" {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.snippet.invalid")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	updated, err := service.Store().Snippets().Update(name, content)
+	if err != nil {
+		return snippetError(err)
+	}
+
+	if !updated {
+		return snippetNotFound(name)
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.snippet.updated"), name)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func SnippetRemoveCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	name := snippetName(data.Options.Find("name").String())
+
+	removed, err := service.Store().Snippets().Delete(name)
+	if err != nil {
+		return snippetError(err)
+	}
+
+	if !removed {
+		return snippetNotFound(name)
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.snippet.removed"), name)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func SnippetListCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	snippets, err := service.Store().Snippets().List()
+	if err != nil {
+		return snippetError(err)
+	}
+
+	if len(snippets) == 0 {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.snippet.empty")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	names := make([]string, 0, len(snippets))
+	for _, snippet := range snippets {
+		names = append(names, "`"+snippet.Name+"`")
+	}
+
+	return &api.InteractionResponseData{
+		Embeds: &[]discord.Embed{{
+			Title:       language.GetTranslation("commands.snippet.list_title"),
+			Description: messages.TruncateLines(names, maxSnippetListLength),
+			Color:       colors.GetColor(colors.Blue),
+		}},
+		Flags: discord.EphemeralMessage,
+	}
+}
+
+func SnippetViewCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	name := snippetName(data.Options.Find("name").String())
+
+	snippet, err := service.Store().Snippets().FindByName(name)
+	if err != nil {
+		return snippetError(err)
+	}
+
+	if snippet == nil {
+		return snippetNotFound(name)
+	}
+
+	return &api.InteractionResponseData{
+		Embeds: &[]discord.Embed{{
+			Title:       snippet.Name,
+			Description: snippet.Content,
+			Color:       colors.GetColor(colors.Blue),
+		}},
+		Flags: discord.EphemeralMessage,
+	}
+}
+
+func SnippetSendCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	name := snippetName(data.Options.Find("name").String())
+	anonymous, _ := data.Options.Find("anonymous").BoolValue()
+
+	snippet, err := service.Store().Snippets().FindByName(name)
+	if err != nil {
+		return snippetError(err)
+	}
+
+	if snippet == nil {
+		return snippetNotFound(name)
+	}
+
+	ticketOwner, response := replyRecipient(ctx, data)
+	if response != nil {
+		return response
+	}
+
+	staff := data.Event.Member.User
+
+	return sendReply(service, ticketOwner, tickets.SlashCommandMessage{
+		Message:   expandSnippet(service, snippet.Content, ticketOwner, &staff),
+		Author:    staff,
+		Anonymous: anonymous,
+	})
+}
+
+// SnippetAutocomplete suggests snippet names matching what was typed so far
+func SnippetAutocomplete(ctx context.Context, service *services.BotService, data cmdroute.AutocompleteData) api.AutocompleteChoices {
+	typed := snippetName(data.Options.Focused().String())
+
+	snippets, err := service.Store().Snippets().List()
+	if err != nil {
+		logger.Error(err.Error())
+		return api.AutocompleteStringChoices{}
+	}
+
+	choices := api.AutocompleteStringChoices{}
+	for _, snippet := range snippets {
+		if len(choices) == maxAutocompleteChoices {
+			break
+		}
+
+		if strings.Contains(snippet.Name, typed) {
+			choices = append(choices, discord.StringChoice{Name: snippet.Name, Value: snippet.Name})
+		}
+	}
+
+	return choices
+}
+
+// expandSnippet fills in the {user}, {staff} and {server} placeholders of a snippet
+func expandSnippet(service *services.BotService, content string, user *discord.User, staff *discord.User) string {
+	server := ""
+	if guild, err := service.State().Guild(service.Config().Discord.GuildID); err == nil {
+		server = guild.Name
+	}
+
+	return strings.NewReplacer(
+		"{user}", user.DisplayOrUsername(),
+		"{staff}", staff.DisplayOrUsername(),
+		"{server}", server,
+	).Replace(content)
+}
+
+// snippetName normalises a snippet name, so names are matched case-insensitively
+func snippetName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// snippetNotFound is the response for a snippet that does not exist
+func snippetNotFound(name string) *api.InteractionResponseData {
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation("commands.snippet.not_found"), name)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+// snippetError logs a storage error and responds with a generic error
+func snippetError(err error) *api.InteractionResponseData {
+	logger.Error(err.Error())
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation("commands.snippet.error")),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func init() {
+	name := func(description string) *discord.StringOption {
+		return &discord.StringOption{
+			OptionName:   "name",
+			Description:  description,
+			Required:     true,
+			MaxLength:    option.NewInt(maxSnippetNameLength),
+			Autocomplete: true,
+		}
+	}
+
+	content := &discord.StringOption{
+		OptionName:  "content",
+		Description: "The content of the snippet, may use {user}, {staff} and {server}",
+		Required:    true,
+		MaxLength:   option.NewInt(maxEditLength),
+	}
+
+	Register(&Command{
+		Name:        "snippet",
+		Description: "Manage and send saved responses",
+		Permission:  config.PermissionSupporter,
+		Subcommands: []*Command{
+			{
+				Name:        "add",
+				Description: "Save a new snippet",
+				Permission:  config.PermissionModerator,
+				Handler:     SnippetAddCommand,
+				Options: []discord.CommandOptionValue{
+					&discord.StringOption{
+						OptionName:  "name",
+						Description: "The name of the snippet",
+						Required:    true,
+						MaxLength:   option.NewInt(maxSnippetNameLength),
+					},
+					content,
+				},
+			},
+			{
+				Name:         "edit",
+				Description:  "Change the content of a snippet",
+				Permission:   config.PermissionModerator,
+				Handler:      SnippetEditCommand,
+				Autocomplete: SnippetAutocomplete,
+				Options:      []discord.CommandOptionValue{name("The snippet to change"), content},
+			},
+			{
+				Name:         "remove",
+				Description:  "Remove a snippet",
+				Permission:   config.PermissionModerator,
+				Handler:      SnippetRemoveCommand,
+				Autocomplete: SnippetAutocomplete,
+				Options:      []discord.CommandOptionValue{name("The snippet to remove")},
+			},
+			{
+				Name:        "list",
+				Description: "List all snippets",
+				Handler:     SnippetListCommand,
+			},
+			{
+				Name:         "view",
+				Description:  "Show the content of a snippet",
+				Handler:      SnippetViewCommand,
+				Autocomplete: SnippetAutocomplete,
+				Options:      []discord.CommandOptionValue{name("The snippet to show")},
+			},
+			{
+				Name:         "send",
+				Description:  "Send a snippet to the user of this ticket",
+				InTicket:     true,
+				Deferred:     true,
+				Handler:      SnippetSendCommand,
+				Autocomplete: SnippetAutocomplete,
+				Options: []discord.CommandOptionValue{
+					name("The snippet to send"),
+					&discord.BooleanOption{
+						OptionName:  "anonymous",
+						Description: "Hide your name from the user, staff still see who replied",
+					},
+					forceOption,
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/colors"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/commands/helpers/messages"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// maxTagLength is the longest tag that is accepted
+const maxTagLength = 32
+
+// maxTagSearchResults is the number of tickets a tag search shows
+const maxTagSearchResults = 25
+
+// maxTagListLength keeps the tag list within a single embed description
+const maxTagListLength = 4000
+
+func TagAddCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	tag := tagName(data.Options.Find("name").String())
+	if tag == "" {
+		return tagResponse("commands.tag.invalid")
+	}
+
+	record := tickets.FromContext(ctx).Record
+
+	added, err := service.Store().Tags().Add(record.ID, tag)
+	if err != nil {
+		return tagError(err)
+	}
+
+	if !added {
+		return tagResponse("commands.tag.already_tagged", tag)
+	}
+
+	return tagResponse("commands.tag.added", tag)
+}
+
+func TagRemoveCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	tag := tagName(data.Options.Find("name").String())
+
+	record := tickets.FromContext(ctx).Record
+
+	removed, err := service.Store().Tags().Remove(record.ID, tag)
+	if err != nil {
+		return tagError(err)
+	}
+
+	if !removed {
+		return tagResponse("commands.tag.not_tagged", tag)
+	}
+
+	return tagResponse("commands.tag.removed", tag)
+}
+
+func TagListCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	counts, err := service.Store().Tags().Count()
+	if err != nil {
+		return tagError(err)
+	}
+
+	if len(counts) == 0 {
+		return tagResponse("commands.tag.empty")
+	}
+
+	lines := make([]string, 0, len(counts))
+	for _, count := range counts {
+		lines = append(lines, fmt.Sprintf(language.GetTranslation("commands.tag.count"), count.Tag, count.Count))
+	}
+
+	return &api.InteractionResponseData{
+		Embeds: &[]discord.Embed{{
+			Title:       language.GetTranslation("commands.tag.list_title"),
+			Description: messages.TruncateLines(lines, maxTagListLength),
+			Color:       colors.GetColor(colors.Blue),
+		}},
+		Flags: discord.EphemeralMessage,
+	}
+}
+
+func TagSearchCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	tag := tagName(data.Options.Find("name").String())
+
+	found, err := service.Store().Tags().FindTickets(tag, maxTagSearchResults)
+	if err != nil {
+		return tagError(err)
+	}
+
+	if len(found) == 0 {
+		return tagResponse("commands.tag.no_results", tag)
+	}
+
+	lines := make([]string, 0, len(found))
+	for _, record := range found {
+		line := fmt.Sprintf("#%d • %s • %s", record.ID, record.UserID.Mention(), record.Status)
+		if record.IsOpen() {
+			line += " • " + record.ChannelID.Mention()
+		}
+		lines = append(lines, line)
+	}
+
+	return &api.InteractionResponseData{
+		Embeds: &[]discord.Embed{{
+			Title:       fmt.Sprintf(language.GetTranslation("commands.tag.search_title"), tag),
+			Description: strings.Join(lines, "\n"),
+			Color:       colors.GetColor(colors.Blue),
+		}},
+		Flags: discord.EphemeralMessage,
+	}
+}
+
+// TagAutocomplete suggests the tags in use, most used first
+func TagAutocomplete(ctx context.Context, service *services.BotService, data cmdroute.AutocompleteData) api.AutocompleteChoices {
+	counts, err := service.Store().Tags().Count()
+	if err != nil {
+		logger.Error(err.Error())
+		return api.AutocompleteStringChoices{}
+	}
+
+	tags := make([]string, 0, len(counts))
+	for _, count := range counts {
+		tags = append(tags, count.Tag)
+	}
+
+	return tagChoices(tags, data.Options.Focused().String())
+}
+
+// TicketTagAutocomplete suggests the tags of the ticket the command is used in
+func TicketTagAutocomplete(ctx context.Context, service *services.BotService, data cmdroute.AutocompleteData) api.AutocompleteChoices {
+	record, err := service.Store().Tickets().FindOpenByChannel(data.Event.ChannelID)
+	if err != nil || record == nil {
+		return api.AutocompleteStringChoices{}
+	}
+
+	tags, err := service.Store().Tags().ListByTicket(record.ID)
+	if err != nil {
+		logger.Error(err.Error())
+		return api.AutocompleteStringChoices{}
+	}
+
+	return tagChoices(tags, data.Options.Focused().String())
+}
+
+// tagChoices turns the tags matching what was typed so far into autocomplete choices
+func tagChoices(tags []string, typed string) api.AutocompleteChoices {
+	typed = tagName(typed)
+
+	choices := api.AutocompleteStringChoices{}
+	for _, tag := range tags {
+		if len(choices) == maxAutocompleteChoices {
+			break
+		}
+
+		if strings.Contains(tag, typed) {
+			choices = append(choices, discord.StringChoice{Name: tag, Value: tag})
+		}
+	}
+
+	return choices
+}
+
+// tagName normalises a tag, so tags are matched case-insensitively and can't contain spaces
+func tagName(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+}
+
+// tagResponse is an ephemeral response with a translated message
+func tagResponse(key string, args ...any) *api.InteractionResponseData {
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(language.GetTranslation(key), args...)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+// tagError logs a storage error and responds with a generic error
+func tagError(err error) *api.InteractionResponseData {
+	logger.Error(err.Error())
+
+	return tagResponse("commands.tag.error")
+}
+
+func init() {
+	name := func(description string) *discord.StringOption {
+		return &discord.StringOption{
+			OptionName:   "name",
+			Description:  description,
+			Required:     true,
+			MaxLength:    option.NewInt(maxTagLength),
+			Autocomplete: true,
+		}
+	}
+
+	Register(&Command{
+		Name:        "tag",
+		Description: "Tag tickets and find tickets by tag",
+		Permission:  config.PermissionSupporter,
+		Subcommands: []*Command{
+			{
+				Name:         "add",
+				Description:  "Add a tag to this ticket",
+				InTicket:     true,
+				Handler:      TagAddCommand,
+				Autocomplete: TagAutocomplete,
+				Options:      []discord.CommandOptionValue{name("The tag to add")},
+			},
+			{
+				Name:         "remove",
+				Description:  "Remove a tag from this ticket",
+				InTicket:     true,
+				Handler:      TagRemoveCommand,
+				Autocomplete: TicketTagAutocomplete,
+				Options:      []discord.CommandOptionValue{name("The tag to remove")},
+			},
+			{
+				Name:        "list",
+				Description: "List all tags and how many tickets have them",
+				Handler:     TagListCommand,
+			},
+			{
+				Name:         "search",
+				Description:  "Find the most recent tickets with a tag",
+				Handler:      TagSearchCommand,
+				Autocomplete: TagAutocomplete,
+				Options:      []discord.CommandOptionValue{name("The tag to search for")},
+			},
+		},
+	})
+}
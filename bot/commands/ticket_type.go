@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/config"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// TicketTypeComponent opens the ticket of a user once they chose its type from the select menu
+func TicketTypeComponent(ctx context.Context, service *services.BotService, data cmdroute.ComponentData) *api.InteractionResponse {
+	selected, ok := data.ComponentInteraction.(*discord.StringSelectInteraction)
+	if !ok || len(selected.Values) == 0 {
+		return promptResponse(language.GetTranslation("tickets.confirm.expired"), nil)
+	}
+
+	ticketType := service.Config().TicketType(selected.Values[0])
+	author := data.Event.Sender()
+	if ticketType == nil || author == nil {
+		return promptResponse(language.GetTranslation("tickets.confirm.expired"), nil)
+	}
+
+	held := tickets.TakePendingMessages(author.ID)
+	if len(held) == 0 {
+		return promptResponse(language.GetTranslation("tickets.confirm.expired"), nil)
+	}
+
+	openPendingTicket(service, *author, held, ticketType)
+
+	return promptResponse(fmt.Sprintf(language.GetTranslation("tickets.types.chosen"), ticketType.Name), nil)
+}
+
+// openPendingTicket opens the ticket of a user who answered their prompt in the background,
+// since relaying every held message can take longer than Discord waits for a response
+func openPendingTicket(service *services.BotService, author discord.User, held []discord.Message, ticketType *config.TicketType) {
+	go func() {
+		if err := tickets.OpenPendingTicket(service.Config(), service.State(), service.Store(), author, held, ticketType); err != nil {
+			logger.Error(err.Error())
+
+			if _, err := service.State().SendMessageReply(held[0].ChannelID, language.GetTranslation("tickets.relay.failed"), held[0].ID); err != nil {
+				logger.Error(err.Error())
+			}
+		}
+	}()
+}
+
+// promptResponse replaces a prompt with a message and the given components, or none so it can't be used twice
+func promptResponse(content string, components discord.ContainerComponents) *api.InteractionResponse {
+	if components == nil {
+		components = discord.ContainerComponents{}
+	}
+
+	return &api.InteractionResponse{
+		Type: api.UpdateMessage,
+		Data: &api.InteractionResponseData{
+			Content:    option.NewNullableString(content),
+			Components: &components,
+		},
+	}
+}
+
+func init() {
+	RegisterComponent(tickets.TicketTypeComponentID, TicketTypeComponent)
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/bot/transcripts"
+	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TranscriptCommand(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+	var (
+		record *database.Ticket
+		err    error
+	)
+
+	// Resolve the ticket by ID, by user, or from the channel the command was used in
+	if ticketOption := data.Options.Find("ticket"); ticketOption.Name != "" {
+		id, parseErr := ticketOption.IntValue()
+		if parseErr != nil {
+			return &api.InteractionResponseData{
+				Content: option.NewNullableString(language.GetTranslation("general.errors.generic")),
+				Flags:   discord.EphemeralMessage,
+			}
+		}
+		record, err = service.Store().Tickets().FindByID(id)
+	} else if userOption := data.Options.Find("user"); userOption.Name != "" {
+		userID, parseErr := userOption.SnowflakeValue()
+		if parseErr != nil {
+			return &api.InteractionResponseData{
+				Content: option.NewNullableString(language.GetTranslation("general.errors.generic")),
+				Flags:   discord.EphemeralMessage,
+			}
+		}
+		record, err = service.Store().Tickets().FindLatestByUser(discord.UserID(userID))
+	} else {
+		record, err = service.Store().Tickets().FindOpenByChannel(data.Event.ChannelID)
+	}
+
+	if err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.transcript.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	if record == nil {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.transcript.not_found")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	format := transcripts.FormatHTML
+	if formatOption := data.Options.Find("format"); formatOption.Name != "" {
+		format = transcripts.Format(formatOption.String())
+	}
+
+	transcript, err := tickets.BuildTranscript(service.State(), service.Store(), record)
+	if err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.transcript.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	files, err := transcript.Files(format)
+	if err != nil {
+		logger.Error(err.Error())
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(language.GetTranslation("commands.transcript.error")),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	return &api.InteractionResponseData{
+		Files: files,
+		Flags: discord.EphemeralMessage,
+	}
+}
+
+func init() {
+	Register(&Command{
+		Name:        "transcript",
+		Description: "Get the transcript of a ModMail ticket",
+		Permission:  config.PermissionSupporter,
+		Deferred:    true,
+		Handler:     TranscriptCommand,
+		Options: []discord.CommandOptionValue{
+			&discord.IntegerOption{
+				OptionName:  "ticket",
+				Description: "The ID of the ticket",
+			},
+			&discord.UserOption{
+				OptionName:  "user",
+				Description: "Get the most recent ticket of this user",
+			},
+			&discord.StringOption{
+				OptionName:  "format",
+				Description: "The format of the transcript",
+				Choices: []discord.StringChoice{
+					{Name: "HTML", Value: string(transcripts.FormatHTML)},
+					{Name: "Markdown", Value: string(transcripts.FormatMarkdown)},
+					{Name: "JSON", Value: string(transcripts.FormatJSON)},
+				},
+			},
+		},
+	})
+}
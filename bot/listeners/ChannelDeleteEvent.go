@@ -5,11 +5,24 @@ import (
 	"discord-bot-tickets/bot/tickets"
 	logger "discord-bot-tickets/logging"
 
+	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
 )
 
 // HandleChannelDelete handles channel deletion events and cleans up the ticket cache
 func HandleChannelDelete(service *services.BotService, event *gateway.ChannelDeleteEvent) {
+	// Close the stored ticket if the channel was deleted without using /close
+	record, err := tickets.MarkTicketClosed(service.Store(), event.Channel.ID, discord.NullUserID, "")
+	if err != nil {
+		logger.Error(err.Error())
+	} else if record != nil {
+		logger.Info("Marked ticket %d as closed as its channel %s was deleted", record.ID, event.Channel.ID.String())
+
+		if err := tickets.ArchiveTicket(service.Config(), service.State(), service.Store(), record); err != nil {
+			logger.Error("Failed to archive ticket %d: %v", record.ID, err)
+		}
+	}
+
 	// Check if the deleted channel was a ticket
 	isTicket, err := tickets.IsChannelTicket(*service.State(), &event.Channel)
 	if err != nil {
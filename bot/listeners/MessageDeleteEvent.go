@@ -0,0 +1,17 @@
+package listeners
+
+import (
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	logger "discord-bot-tickets/logging"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+// HandleMessageDelete mirrors deletions of relayed messages, both DMs deleted by
+// the user and ticket channel copies of staff replies deleted by staff
+func HandleMessageDelete(service *services.BotService, event *gateway.MessageDeleteEvent) {
+	if err := tickets.SyncDelete(service.State(), service.Store(), event.ID); err != nil {
+		logger.Error("Failed to sync deletion of message %s: %v", event.ID, err)
+	}
+}
@@ -0,0 +1,26 @@
+package listeners
+
+import (
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	logger "discord-bot-tickets/logging"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+// HandleMessageUpdate mirrors edits of relayed DMs into the ticket channel
+func HandleMessageUpdate(service *services.BotService, event *gateway.MessageUpdateEvent) {
+	// Only user DMs are relayed as they are, staff replies are sent through commands
+	if event.GuildID.IsValid() || event.Author.Bot {
+		return
+	}
+
+	// Partial updates, such as link previews being added, carry no author or content
+	if !event.Author.ID.IsValid() {
+		return
+	}
+
+	if err := tickets.SyncEdit(service.State(), service.Store(), event.ID, event.Content); err != nil {
+		logger.Error("Failed to sync edit of message %s: %v", event.ID, err)
+	}
+}
@@ -11,6 +11,14 @@ func RegisterListeners(service *services.BotService) {
 		HandleMessageCreate(service, event)
 	})
 
+	service.State().AddHandler(func(event *gateway.MessageUpdateEvent) {
+		HandleMessageUpdate(service, event)
+	})
+
+	service.State().AddHandler(func(event *gateway.MessageDeleteEvent) {
+		HandleMessageDelete(service, event)
+	})
+
 	service.State().AddHandler(func(event *gateway.ChannelDeleteEvent) {
 		HandleChannelDelete(service, event)
 	})
@@ -1,10 +1,14 @@
 package listeners
 
 import (
+	"discord-bot-tickets/bot/commands/helpers/language"
 	"discord-bot-tickets/bot/commands/helpers/messages"
 	"discord-bot-tickets/bot/services"
 	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/database"
 	logger "discord-bot-tickets/logging"
+	"fmt"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/gateway"
 )
@@ -14,23 +18,59 @@ func HandleMessageCreate(service *services.BotService, event *gateway.MessageCre
 		return
 	}
 
+	// Only direct messages are relayed, server messages never need the ticket lookup
+	if event.GuildID.IsValid() {
+		return
+	}
+
 	// Check if the user has an active ticket
-	ticket, err := tickets.GetActiveTicket(service.Config(), service.State(), &event.Author)
+	ticket, err := tickets.GetActiveTicket(service.Config(), service.State(), service.Store(), &event.Author)
 	if err != nil {
 		return
 	}
 
-	if event.GuildID.IsValid() {
+	block, err := service.Store().Blocks().FindActive(event.Author.ID, time.Now())
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	if block != nil {
+		notifyBlocked(service, event, block)
 		return
 	}
 
 	if ticket != nil {
-		if err = tickets.UpdateTicket(service.Config(), service.State(), event.Author, tickets.RegularMessage{Message: event.Message}); err != nil {
+		if err = tickets.UpdateTicket(service.Config(), service.State(), service.Store(), event.Author, tickets.RegularMessage{Message: event.Message}); err != nil {
+			logger.Error(err.Error())
+			notifyRelayFailed(service, event)
+			return
+		}
+
+		// A reply from the user cancels any pending close
+		cancelled, err := tickets.CancelScheduledClose(service.Store(), ticket)
+		if err != nil {
 			logger.Error(err.Error())
+		} else if cancelled {
+			if _, err = service.State().SendMessage(ticket.Channel.ID, language.GetTranslation("commands.close.cancelled_by_reply")); err != nil {
+				logger.Error(err.Error())
+			}
 		}
+	} else if service.Config().Confirmation.Enabled || len(service.Config().TicketTypes) > 0 {
+		// The ticket is opened once the user confirmed it or chose its type, until then their messages are held
+		if tickets.HoldMessage(event.Message) {
+			if err = tickets.PromptNewTicket(service.Config(), service.State(), event.Message); err != nil {
+				logger.Error(err.Error())
+				tickets.ForgetPendingMessages(event.Author.ID)
+				notifyRelayFailed(service, event)
+			}
+		}
+		return
 	} else {
-		if _, err = tickets.CreateTicket(service.Config(), service.State(), event.Author, event.Message); err != nil {
+		if _, err = tickets.CreateTicket(service.Config(), service.State(), service.Store(), event.Author, event.Message, nil); err != nil {
 			logger.Error(err.Error())
+			notifyRelayFailed(service, event)
+			return
 		}
 	}
 
@@ -38,3 +78,22 @@ func HandleMessageCreate(service *services.BotService, event *gateway.MessageCre
 		logger.Error(err.Error())
 	}
 }
+
+// notifyRelayFailed tells the user that their message did not reach the staff
+func notifyRelayFailed(service *services.BotService, event *gateway.MessageCreateEvent) {
+	if _, err := service.State().SendMessageReply(event.ChannelID, language.GetTranslation("tickets.relay.failed"), event.ID); err != nil {
+		logger.Error(err.Error())
+	}
+}
+
+// notifyBlocked tells a blocked user that their message was not relayed
+func notifyBlocked(service *services.BotService, event *gateway.MessageCreateEvent, block *database.Block) {
+	content := language.GetTranslation("tickets.blocked.permanent")
+	if block.ExpiresAt != nil {
+		content = fmt.Sprintf(language.GetTranslation("tickets.blocked.temporary"), fmt.Sprintf("<t:%d:f>", block.ExpiresAt.Unix()))
+	}
+
+	if _, err := service.State().SendMessageReply(event.ChannelID, content, event.ID); err != nil {
+		logger.Error(err.Error())
+	}
+}
@@ -0,0 +1,327 @@
+package bot
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/commands/helpers/permissions"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	logger "discord-bot-tickets/logging"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// Middleware wraps a command handler with behaviour shared by several commands.
+// For a subcommand the command is the one returned by commands.Command.Inherit,
+// so its name is the full name, e.g. "snippet add".
+type Middleware func(command *commands.Command, next commands.CommandHandler) commands.CommandHandler
+
+// DefaultMiddleware runs around every command, the first middleware is the outermost
+var DefaultMiddleware = []Middleware{
+	Recover,
+	Timing,
+	Logging,
+	RequirePermission,
+}
+
+// slowCommandThreshold is how long a command may take before it is logged as slow
+const slowCommandThreshold = 2 * time.Second
+
+// Chain wraps the handler of a command in the given middleware, the first middleware is the outermost
+//
+// Returns: the wrapped CommandHandler
+func Chain(command *commands.Command, handler commands.CommandHandler, middleware ...Middleware) commands.CommandHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](command, handler)
+	}
+
+	return handler
+}
+
+// commandMiddleware gets the middleware a command runs in, DefaultMiddleware and whatever its settings add
+func commandMiddleware(command *commands.Command) []Middleware {
+	middleware := slices.Clone(DefaultMiddleware)
+
+	if command.InTicket {
+		middleware = append(middleware, RequireTicket)
+	}
+
+	return middleware
+}
+
+// Recover turns a panicking command into an error response, so one broken command can't take the bot down
+func Recover(command *commands.Command, next commands.CommandHandler) commands.CommandHandler {
+	return func(ctx context.Context, service *services.BotService, data cmdroute.CommandData) (response *api.InteractionResponseData) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("Command /%s panicked: %v\n%s", command.Name, r, debug.Stack())
+				response = errorResponse("general.errors.generic")
+			}
+		}()
+
+		return next(ctx, service, data)
+	}
+}
+
+// Logging logs who used a command and where
+func Logging(command *commands.Command, next commands.CommandHandler) commands.CommandHandler {
+	return func(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+		if sender := data.Event.Sender(); sender != nil {
+			logger.Info("%s (%s) used /%s in channel %s", sender.Username, sender.ID, command.Name, data.Event.ChannelID)
+		}
+
+		return next(ctx, service, data)
+	}
+}
+
+// Timing records how long a command took, see CommandTimings
+func Timing(command *commands.Command, next commands.CommandHandler) commands.CommandHandler {
+	return func(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+		start := time.Now()
+		defer func() {
+			elapsed := time.Since(start)
+			commandTimings.record(command.Name, elapsed)
+
+			if elapsed > slowCommandThreshold {
+				logger.Warn("Command /%s took %s", command.Name, elapsed)
+			}
+		}()
+
+		return next(ctx, service, data)
+	}
+}
+
+// RequirePermission refuses members below the permission level the command requires
+func RequirePermission(command *commands.Command, next commands.CommandHandler) commands.CommandHandler {
+	required := command.Permission
+
+	return func(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+		if !permissions.Allowed(service.Config(), data.Event.Member, required) {
+			return permissions.Denied(required)
+		}
+
+		return next(ctx, service, data)
+	}
+}
+
+// RequireAutocompletePermission suggests nothing to members below the permission level of the
+// command, so the names of staff-only data are not listed to everyone
+func RequireAutocompletePermission(command *commands.Command, next commands.AutocompleteHandler) commands.AutocompleteHandler {
+	required := command.Permission
+
+	return func(ctx context.Context, service *services.BotService, data cmdroute.AutocompleteData) api.AutocompleteChoices {
+		if !permissions.Allowed(service.Config(), data.Event.Member, required) {
+			return api.AutocompleteStringChoices{}
+		}
+
+		return next(ctx, service, data)
+	}
+}
+
+// RequireTicket only runs a command in an open ticket channel, and passes the ticket
+// on in the context, where the handler gets it with tickets.FromContext
+func RequireTicket(command *commands.Command, next commands.CommandHandler) commands.CommandHandler {
+	return func(ctx context.Context, service *services.BotService, data cmdroute.CommandData) *api.InteractionResponseData {
+		ticket, err := tickets.FindChannelTicket(service.Config(), service.State(), service.Store(), data.Event.ChannelID)
+		if err != nil {
+			logger.Error("Failed to find the ticket of channel %s: %v", data.Event.ChannelID, err)
+			return errorResponse("general.errors.generic")
+		}
+
+		if ticket == nil {
+			return errorResponse("general.errors.not_a_ticket")
+		}
+
+		return next(tickets.NewContext(ctx, ticket), service, data)
+	}
+}
+
+// ComponentMiddleware wraps a message component handler, like Middleware does for commands
+type ComponentMiddleware func(component *commands.Component, next commands.ComponentHandler) commands.ComponentHandler
+
+// DefaultComponentMiddleware runs around every message component, the first middleware is the outermost
+var DefaultComponentMiddleware = []ComponentMiddleware{
+	RecoverComponent,
+	TimingComponent,
+	LoggingComponent,
+	RequireComponentPermission,
+}
+
+// ChainComponent wraps the handler of a component in the given middleware, the first middleware is the outermost
+//
+// Returns: the wrapped ComponentHandler
+func ChainComponent(component *commands.Component, handler commands.ComponentHandler, middleware ...ComponentMiddleware) commands.ComponentHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](component, handler)
+	}
+
+	return handler
+}
+
+// RecoverComponent turns a panicking component handler into an error response
+func RecoverComponent(component *commands.Component, next commands.ComponentHandler) commands.ComponentHandler {
+	return func(ctx context.Context, service *services.BotService, data cmdroute.ComponentData) (response *api.InteractionResponse) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("Component %s panicked: %v\n%s", component.ID, r, debug.Stack())
+				response = componentResponse(errorResponse("general.errors.generic"))
+			}
+		}()
+
+		return next(ctx, service, data)
+	}
+}
+
+// LoggingComponent logs who used a component and where
+func LoggingComponent(component *commands.Component, next commands.ComponentHandler) commands.ComponentHandler {
+	return func(ctx context.Context, service *services.BotService, data cmdroute.ComponentData) *api.InteractionResponse {
+		if sender := data.Event.Sender(); sender != nil {
+			logger.Info("%s (%s) used component %s in channel %s", sender.Username, sender.ID, component.ID, data.Event.ChannelID)
+		}
+
+		return next(ctx, service, data)
+	}
+}
+
+// TimingComponent records how long a component handler took, under "component <custom ID>"
+// in CommandTimings
+func TimingComponent(component *commands.Component, next commands.ComponentHandler) commands.ComponentHandler {
+	name := "component " + component.ID
+
+	return func(ctx context.Context, service *services.BotService, data cmdroute.ComponentData) *api.InteractionResponse {
+		start := time.Now()
+		defer func() {
+			elapsed := time.Since(start)
+			commandTimings.record(name, elapsed)
+
+			if elapsed > slowCommandThreshold {
+				logger.Warn("Component %s took %s", component.ID, elapsed)
+			}
+		}()
+
+		return next(ctx, service, data)
+	}
+}
+
+// RequireComponentPermission refuses members below the permission level of the command that sent the component
+func RequireComponentPermission(component *commands.Component, next commands.ComponentHandler) commands.ComponentHandler {
+	required := component.Permission
+
+	return func(ctx context.Context, service *services.BotService, data cmdroute.ComponentData) *api.InteractionResponse {
+		if !permissions.Allowed(service.Config(), data.Event.Member, required) {
+			return componentResponse(permissions.Denied(required))
+		}
+
+		return next(ctx, service, data)
+	}
+}
+
+// componentResponse sends the response data as a new message, leaving the message of the component as it is
+func componentResponse(data *api.InteractionResponseData) *api.InteractionResponse {
+	return &api.InteractionResponse{
+		Type: api.MessageInteractionWithSource,
+		Data: data,
+	}
+}
+
+// errorResponse is an ephemeral response with the given translation
+func errorResponse(key string) *api.InteractionResponseData {
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(language.GetTranslation(key)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+// CommandTiming sums up how long the runs of a command took
+type CommandTiming struct {
+	Count   int
+	Total   time.Duration
+	Slowest time.Duration
+}
+
+// Average is the mean duration of a run of the command
+func (t CommandTiming) Average() time.Duration {
+	if t.Count == 0 {
+		return 0
+	}
+
+	return t.Total / time.Duration(t.Count)
+}
+
+// timings collects the CommandTiming of every command since the bot started
+type timings struct {
+	commands map[string]CommandTiming
+	mu       sync.Mutex
+}
+
+var commandTimings = &timings{
+	commands: make(map[string]CommandTiming),
+}
+
+// record adds a run of a command
+func (t *timings) record(name string, elapsed time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timing := t.commands[name]
+	timing.Count++
+	timing.Total += elapsed
+	timing.Slowest = max(timing.Slowest, elapsed)
+	t.commands[name] = timing
+}
+
+// timingReportInterval is how often the command timings are written to the log
+const timingReportInterval = time.Hour
+
+// ReportCommandTimings logs the CommandTimings of every command that ran, once every
+// timingReportInterval, until the context is cancelled
+func ReportCommandTimings(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(timingReportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			timings := CommandTimings()
+
+			names := make([]string, 0, len(timings))
+			for name := range timings {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+
+			for _, name := range names {
+				timing := timings[name]
+				logger.Info("Timing of %s: %d runs, %s on average, %s at the slowest", name, timing.Count, timing.Average(), timing.Slowest)
+			}
+		}
+	}()
+}
+
+// CommandTimings gets how long each command took since the bot started
+//
+// Returns: a copy of the timings keyed by command name
+func CommandTimings() map[string]CommandTiming {
+	commandTimings.mu.Lock()
+	defer commandTimings.mu.Unlock()
+
+	snapshot := make(map[string]CommandTiming, len(commandTimings.commands))
+	for name, timing := range commandTimings.commands {
+		snapshot[name] = timing
+	}
+
+	return snapshot
+}
@@ -0,0 +1,21 @@
+package scheduler
+
+import (
+	"discord-bot-tickets/bot/services"
+	logger "discord-bot-tickets/logging"
+	"time"
+)
+
+// runBlockExpiry removes temporary blocks that have run out. Expired blocks are
+// already ignored when messages come in, this only keeps the blocklist tidy.
+func runBlockExpiry(service *services.BotService) {
+	removed, err := service.Store().Blocks().DeleteExpired(time.Now())
+	if err != nil {
+		logger.Error("Failed to remove expired blocks: %v", err)
+		return
+	}
+
+	if removed > 0 {
+		logger.Info("Removed %d expired blocks", removed)
+	}
+}
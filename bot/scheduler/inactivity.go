@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"discord-bot-tickets/bot/commands/helpers/duration"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+	"time"
+)
+
+// runInactivitySweep warns the owners of idle tickets and closes the tickets
+// that stayed idle for the whole warning period afterwards
+func runInactivitySweep(service *services.BotService) {
+	settings := service.Config().Inactivity
+	if settings.Timeout <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	inactive, err := service.Store().Tickets().ListInactiveSince(now.Add(-settings.Timeout))
+	if err != nil {
+		logger.Error("Failed to list inactive tickets: %v", err)
+		return
+	}
+
+	for i := range inactive {
+		record := &inactive[i]
+
+		if record.InactivityWarnedAt == nil {
+			if err := tickets.WarnInactivity(service.State(), service.Store(), record, now.Sub(record.LastActivityAt), settings.WarningPeriod); err != nil {
+				logger.Error("Failed to warn owner of inactive ticket %d: %v", record.ID, err)
+			}
+			continue
+		}
+
+		if now.Sub(*record.InactivityWarnedAt) < settings.WarningPeriod {
+			continue
+		}
+
+		if err := closeInactiveTicket(service, record); err != nil {
+			logger.Error("Failed to close inactive ticket %d: %v", record.ID, err)
+		}
+	}
+}
+
+// closeInactiveTicket closes a single idle ticket through the same path as the close command
+func closeInactiveTicket(service *services.BotService, record *database.Ticket) error {
+	reason := fmt.Sprintf(language.GetTranslation("tickets.inactivity.reason"), duration.Format(service.Config().Inactivity.Timeout))
+
+	me, err := service.State().Me()
+	if err != nil {
+		return err
+	}
+
+	channel, err := service.State().Channel(record.ChannelID)
+	if err != nil {
+		// The channel is gone, so only the stored ticket is left to close
+		_, err := tickets.MarkTicketClosed(service.Store(), record.ChannelID, me.ID, reason)
+		return err
+	}
+
+	if err := tickets.CloseTicket(service.Config(), service.State(), service.Store(), channel, record.UserID, tickets.CloseOptions{
+		Closer: *me,
+		Reason: reason,
+	}); err != nil {
+		return err
+	}
+
+	logger.Info("Closed inactive ticket %d", record.ID)
+
+	return nil
+}
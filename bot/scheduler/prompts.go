@@ -0,0 +1,20 @@
+package scheduler
+
+import (
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	logger "discord-bot-tickets/logging"
+)
+
+// runPromptExpiry drops the held messages of users who didn't confirm or choose
+// the type of their ticket in time. A zero timeout keeps prompts open forever.
+func runPromptExpiry(service *services.BotService) {
+	timeout := service.Config().Confirmation.Timeout
+	if timeout <= 0 {
+		return
+	}
+
+	if expired := tickets.ExpirePrompts(service.State(), timeout); expired > 0 {
+		logger.Info("Expired %d unanswered ticket prompts", expired)
+	}
+}
@@ -0,0 +1,92 @@
+// Package scheduler runs the bot's background jobs, such as closing tickets
+// whose scheduled close is due, sweeping up inactive tickets, lifting
+// expired blocks and expiring unanswered ticket prompts.
+package scheduler
+
+import (
+	"context"
+	"discord-bot-tickets/bot/services"
+	"discord-bot-tickets/bot/tickets"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// Interval is how often the scheduler checks for due jobs
+const Interval = 30 * time.Second
+
+// Start runs the scheduler in the background until the context is cancelled.
+// Due jobs are run once straight away, so anything that became due while the
+// bot was offline is handled right after a restart.
+func Start(ctx context.Context, service *services.BotService) {
+	go func() {
+		ticker := time.NewTicker(Interval)
+		defer ticker.Stop()
+
+		for {
+			runScheduledCloses(service)
+			runInactivitySweep(service)
+			runBlockExpiry(service)
+			runPromptExpiry(service)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// runScheduledCloses closes every ticket whose scheduled close is due
+func runScheduledCloses(service *services.BotService) {
+	due, err := service.Store().ScheduledCloses().ListDue(time.Now())
+	if err != nil {
+		logger.Error("Failed to list scheduled closes: %v", err)
+		return
+	}
+
+	for _, scheduled := range due {
+		if err := runScheduledClose(service, scheduled); err != nil {
+			logger.Error("Failed to run scheduled close of ticket %d: %v", scheduled.TicketID, err)
+		}
+	}
+}
+
+// runScheduledClose closes a single ticket through the same path as the close command
+func runScheduledClose(service *services.BotService, scheduled database.ScheduledClose) error {
+	store := service.Store()
+
+	record, err := store.Tickets().FindByID(scheduled.TicketID)
+	if err != nil {
+		return err
+	}
+
+	// The ticket was closed some other way in the meantime
+	if record == nil || !record.IsOpen() {
+		_, err := store.ScheduledCloses().Cancel(scheduled.TicketID)
+		return err
+	}
+
+	channel, err := service.State().Channel(record.ChannelID)
+	if err != nil {
+		// The channel is gone, so only the stored ticket is left to close
+		_, err := tickets.MarkTicketClosed(store, record.ChannelID, scheduled.ScheduledBy, scheduled.Reason)
+		return err
+	}
+
+	closer, err := service.State().User(scheduled.ScheduledBy)
+	if err != nil {
+		closer = &discord.User{ID: scheduled.ScheduledBy}
+	}
+
+	logger.Info("Running scheduled close of ticket %d", record.ID)
+
+	return tickets.CloseTicket(service.Config(), service.State(), store, channel, record.UserID, tickets.CloseOptions{
+		Closer: *closer,
+		Reason: scheduled.Reason,
+		Silent: scheduled.Silent,
+	})
+}
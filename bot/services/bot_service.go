@@ -2,6 +2,7 @@ package services
 
 import (
 	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
 
 	"github.com/diamondburned/arikawa/v3/session"
 	"github.com/diamondburned/arikawa/v3/state"
@@ -12,14 +13,16 @@ type BotService struct {
 	config  *config.Config
 	state   *state.State
 	session *session.Session
+	store   database.Store
 }
 
 // NewBotService creates a new BotService instance
-func NewBotService(cfg *config.Config, st *state.State) *BotService {
+func NewBotService(cfg *config.Config, st *state.State, store database.Store) *BotService {
 	return &BotService{
 		config:  cfg,
 		state:   st,
 		session: st.Session,
+		store:   store,
 	}
 }
 
@@ -37,3 +40,8 @@ func (s *BotService) State() *state.State {
 func (s *BotService) Session() *session.Session {
 	return s.session
 }
+
+// Store returns the bot's storage backend
+func (s *BotService) Store() database.Store {
+	return s.store
+}
@@ -0,0 +1,115 @@
+package tickets
+
+import (
+	"discord-bot-tickets/bot/commands/helpers/colors"
+	"discord-bot-tickets/bot/commands/helpers/duration"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/transcripts"
+	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+)
+
+// BuildTranscript builds the transcript of a ticket, resolving the name of the ticket owner
+//
+// Returns: a pointer to the Transcript and an error if any
+func BuildTranscript(state *state.State, store database.Store, record *database.Ticket) (*transcripts.Transcript, error) {
+	userName := record.UserID.String()
+	if user, err := state.User(record.UserID); err == nil {
+		userName = user.Username
+	}
+
+	return transcripts.Build(store, record, userName)
+}
+
+// ArchiveTicket posts a summary of a closed ticket to the log channel, together with its transcript.
+// Nothing is posted if no log channel is configured.
+//
+// Returns: an error if any
+func ArchiveTicket(config *config.Config, state *state.State, store database.Store, record *database.Ticket) error {
+	if !config.Discord.LogChannelID.IsValid() {
+		return nil
+	}
+
+	transcript, err := BuildTranscript(state, store, record)
+	if err != nil {
+		return err
+	}
+
+	files, err := transcript.Files(transcripts.Formats...)
+	if err != nil {
+		return err
+	}
+
+	_, err = state.SendMessageComplex(config.Discord.LogChannelID, api.SendMessageData{
+		Embeds: []discord.Embed{summaryEmbed(record, transcript.Messages, transcript.Tags)},
+		Files:  files,
+	})
+
+	return err
+}
+
+// summaryEmbed creates the staff facing summary of a closed ticket
+func summaryEmbed(record *database.Ticket, messages []database.TicketMessage, tags []string) discord.Embed {
+	closedBy := language.GetTranslation("embeds.ticket_summary.unknown_closer")
+	if record.ClosedBy.IsValid() {
+		closedBy = record.ClosedBy.Mention()
+	}
+
+	closedAt := time.Now()
+	if record.ClosedAt != nil {
+		closedAt = *record.ClosedAt
+	}
+
+	reason := record.CloseReason
+	if reason == "" {
+		reason = language.GetTranslation("embeds.ticket_summary.no_reason")
+	}
+
+	claimedBy := language.GetTranslation("embeds.ticket_summary.unclaimed")
+	if record.ClaimedBy.IsValid() {
+		claimedBy = record.ClaimedBy.Mention()
+	}
+
+	tagList := language.GetTranslation("embeds.ticket_summary.no_tags")
+	if len(tags) > 0 {
+		tagList = "`" + strings.Join(tags, "`, `") + "`"
+	}
+
+	var inbound, outbound, notes int
+	for _, message := range messages {
+		switch message.Direction {
+		case database.MessageInbound:
+			inbound++
+		case database.MessageOutbound:
+			outbound++
+		case database.MessageInternal:
+			notes++
+		}
+	}
+
+	return discord.Embed{
+		Title: fmt.Sprintf(language.GetTranslation("embeds.ticket_summary.title"), record.ID),
+		Color: colors.GetColor(colors.Blue),
+		Fields: []discord.EmbedField{
+			{Name: language.GetTranslation("embeds.ticket_summary.opened_by"), Value: record.UserID.Mention(), Inline: true},
+			{Name: language.GetTranslation("embeds.ticket_summary.closed_by"), Value: closedBy, Inline: true},
+			{Name: language.GetTranslation("embeds.ticket_summary.claimed_by"), Value: claimedBy, Inline: true},
+			{Name: language.GetTranslation("embeds.ticket_summary.duration"), Value: duration.Format(closedAt.Sub(record.CreatedAt)), Inline: true},
+			{Name: language.GetTranslation("embeds.ticket_summary.priority"), Value: string(record.Priority), Inline: true},
+			{Name: language.GetTranslation("embeds.ticket_summary.tags"), Value: tagList, Inline: true},
+			{Name: language.GetTranslation("embeds.ticket_summary.messages"), Value: fmt.Sprintf(language.GetTranslation("embeds.ticket_summary.message_counts"), inbound, outbound, notes)},
+			{Name: language.GetTranslation("embeds.ticket_summary.reason"), Value: reason},
+		},
+		Timestamp: discord.NewTimestamp(closedAt),
+		Footer: &discord.EmbedFooter{
+			Text: "ModMail",
+		},
+	}
+}
@@ -0,0 +1,158 @@
+package tickets
+
+import (
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+)
+
+// assignMu guards reserved, so tickets opened at the same time go to different staff members
+var assignMu sync.Mutex
+
+// reserved counts the tickets that are being opened for each staff member, whose
+// assignment is not stored yet
+var reserved = make(map[discord.UserID]int)
+
+// openAssignedTicket opens a ticket assigned to the staff member on duty who is next in line.
+// The staff member is reserved while the ticket opens, so tickets opened at the same time go
+// to different staff members without holding a lock over the requests to Discord. The
+// assignment is only recorded once the ticket exists, a failed open gives up the reservation.
+//
+// Returns: a pointer to a Ticket and an error if any
+func openAssignedTicket(config *config.Config, state *state.State, store database.Store, author discord.User, ticketType *config.TicketType) (*Ticket, error) {
+	assignee := reserveAssignee(store, config.Assignment.Strategy)
+	defer releaseAssignee(assignee)
+
+	return openTicket(config, state, store, author, assignee, ticketType)
+}
+
+// reserveAssignee picks the staff member who gets the next ticket and reserves them until
+// releaseAssignee is called
+//
+// Returns: the staff member, zero if nobody is on duty or they couldn't be looked up
+func reserveAssignee(store database.Store, strategy config.AssignmentStrategy) discord.UserID {
+	assignMu.Lock()
+	defer assignMu.Unlock()
+
+	assignee := nextAssignee(store, strategy)
+	if assignee.IsValid() {
+		reserved[assignee]++
+	}
+
+	return assignee
+}
+
+// releaseAssignee gives up the reservation of a staff member, once their assignment is
+// stored or the ticket failed to open
+func releaseAssignee(staffID discord.UserID) {
+	if !staffID.IsValid() {
+		return
+	}
+
+	assignMu.Lock()
+	defer assignMu.Unlock()
+
+	if reserved[staffID]--; reserved[staffID] <= 0 {
+		delete(reserved, staffID)
+	}
+}
+
+// nextAssignee picks the staff member on duty who gets the next ticket using the given strategy.
+// Reserved staff members count as just assigned, and their reserved tickets as open ones.
+// The caller must hold assignMu.
+//
+// Returns: the staff member, zero if nobody is on duty or they couldn't be looked up
+func nextAssignee(store database.Store, strategy config.AssignmentStrategy) discord.UserID {
+	duties, err := store.Duties().List()
+	if err != nil {
+		logger.Error("Failed to list the staff on duty: %v", err)
+		return discord.NullUserID
+	}
+
+	if len(duties) == 0 {
+		return discord.NullUserID
+	}
+
+	var counts map[discord.UserID]int
+	if strategy == config.AssignLeastLoaded {
+		counts, err = store.Tickets().CountOpenByClaimer()
+		if err != nil {
+			logger.Error("Failed to count open tickets per staff member: %v", err)
+			return discord.NullUserID
+		}
+	}
+
+	now := time.Now()
+	for i := range duties {
+		if pending := reserved[duties[i].UserID]; pending > 0 {
+			duties[i].LastAssignedAt = &now
+			if counts != nil {
+				counts[duties[i].UserID] += pending
+			}
+		}
+	}
+
+	// Without counts every staff member is equally loaded, which leaves plain round-robin
+	next := duties[0]
+	for _, duty := range duties[1:] {
+		if counts[duty.UserID] < counts[next.UserID] ||
+			(counts[duty.UserID] == counts[next.UserID] && assignedBefore(duty, next)) {
+			next = duty
+		}
+	}
+
+	return next.UserID
+}
+
+// recordAssignment moves a staff member to the back of the rotation once they were given a ticket
+func recordAssignment(store database.Store, staffID discord.UserID) {
+	if err := store.Duties().MarkAssigned(staffID, time.Now()); err != nil {
+		logger.Error("Failed to record the assignment of %s: %v", staffID, err)
+	}
+}
+
+// assignedBefore checks if a staff member went longer without a ticket than another.
+// Staff who haven't had a ticket yet come first.
+func assignedBefore(a database.Duty, b database.Duty) bool {
+	if a.LastAssignedAt == nil {
+		return b.LastAssignedAt != nil
+	}
+
+	return b.LastAssignedAt != nil && a.LastAssignedAt.Before(*b.LastAssignedAt)
+}
+
+// pingStaff lets the role of a ticket type and the assignee know a new ticket was opened.
+// Either may be zero, in which case it isn't pinged.
+func pingStaff(state *state.State, ticket *Ticket, roleID discord.RoleID, assignee discord.UserID) {
+	var (
+		lines    []string
+		mentions api.AllowedMentions
+	)
+
+	if roleID.IsValid() {
+		lines = append(lines, fmt.Sprintf(language.GetTranslation("tickets.assignment.new_ticket"), roleID.Mention()))
+		mentions.Roles = append(mentions.Roles, roleID)
+	}
+
+	if assignee.IsValid() {
+		lines = append(lines, fmt.Sprintf(language.GetTranslation("tickets.assignment.assigned"), assignee.Mention()))
+		mentions.Users = append(mentions.Users, assignee)
+	}
+
+	_, err := state.SendMessageComplex(ticket.Channel.ID, api.SendMessageData{
+		Content:         strings.Join(lines, "\n"),
+		AllowedMentions: &mentions,
+	})
+	if err != nil {
+		logger.Error("Failed to ping the staff of ticket %d: %v", ticket.Record.ID, err)
+	}
+}
@@ -0,0 +1,84 @@
+package tickets
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// topicPrefix marks the ticket owner in the topic of a ticket channel
+const topicPrefix = "User: "
+
+// topicTimeout is how long a topic update may wait. Discord only allows a
+// couple of topic changes per channel every few minutes, so a rate limited
+// update is given up on rather than holding up the command.
+const topicTimeout = 5 * time.Second
+
+// AssignTicket assigns a ticket to a staff member and shows them in the topic
+// of the ticket channel. A zero staffID releases the ticket.
+//
+// Returns: an error if any
+func AssignTicket(state *state.State, store database.Store, record *database.Ticket, staffID discord.UserID) error {
+	if err := store.Tickets().Assign(record.ID, staffID); err != nil {
+		return err
+	}
+
+	record.ClaimedBy = staffID
+
+	if ticket := ticketCache.GetTicket(record.UserID); ticket != nil && ticket.Record != nil && ticket.Record.ID == record.ID {
+		ticket.Record.ClaimedBy = staffID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), topicTimeout)
+	defer cancel()
+
+	err := state.WithContext(ctx).ModifyChannel(record.ChannelID, api.ModifyChannelData{
+		Topic: option.NewNullableString(ticketTopic(record.UserID, staffID)),
+	})
+	if err != nil {
+		logger.Warn("Failed to update the topic of ticket %d: %v", record.ID, err)
+	}
+
+	return nil
+}
+
+// ticketTopic builds the topic of a ticket channel, naming the claimer if there is one
+func ticketTopic(userID discord.UserID, claimedBy discord.UserID) string {
+	topic := topicPrefix + userID.String()
+	if claimedBy.IsValid() {
+		topic += "\n" + fmt.Sprintf(language.GetTranslation("tickets.claim.topic"), claimedBy.Mention())
+	}
+
+	return topic
+}
+
+// topicUserID reads the ticket owner from the topic of a channel
+//
+// Returns: the user ID, zero if the topic doesn't name one, and an error if the ID is malformed
+func topicUserID(topic string) (discord.UserID, error) {
+	index := strings.Index(topic, topicPrefix)
+	if index == -1 {
+		return discord.NullUserID, nil
+	}
+
+	fields := strings.Fields(topic[index+len(topicPrefix):])
+	if len(fields) == 0 {
+		return discord.NullUserID, nil
+	}
+
+	id, err := discord.ParseSnowflake(fields[0])
+	if err != nil {
+		return discord.NullUserID, err
+	}
+
+	return discord.UserID(id), nil
+}
@@ -0,0 +1,164 @@
+package tickets
+
+import (
+	"discord-bot-tickets/bot/commands/helpers/colors"
+	"discord-bot-tickets/bot/commands/helpers/duration"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+)
+
+// CloseOptions describes who closes a ticket and how
+type CloseOptions struct {
+	Closer discord.User
+	Reason string
+	// Silent keeps the reason visible to staff only
+	Silent bool
+}
+
+// CloseTicket closes the ticket of a channel. It marks the ticket as closed, notifies
+// the ticket owner, posts the summary to the log channel and deletes the channel.
+//
+// Returns: an error if any
+func CloseTicket(config *config.Config, state *state.State, store database.Store, channel *discord.Channel, owner discord.UserID, options CloseOptions) error {
+	// Mark the ticket as closed before the channel is deleted
+	record, err := MarkTicketClosed(store, channel.ID, options.Closer.ID, options.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to mark ticket as closed: %w", err)
+	}
+
+	// Create an embed to notify the user
+	embed := discord.Embed{
+		Title:       language.GetTranslation("embeds.ticket_closed.title"),
+		Description: language.GetTranslation("embeds.ticket_closed.description"),
+		Color:       0xFF0000, // Red color
+		Footer: &discord.EmbedFooter{
+			Text: language.GetTranslation("embeds.ticket_closed.footer"),
+		},
+	}
+
+	// Only share the reason with the user if the close is not silent
+	if options.Reason != "" && !options.Silent {
+		embed.Fields = append(embed.Fields, discord.EmbedField{
+			Name:  language.GetTranslation("embeds.ticket_closed.reason"),
+			Value: options.Reason,
+		})
+	}
+
+	// Create DM channel with the user
+	dmChannel, err := state.CreatePrivateChannel(owner)
+	if err != nil {
+		logger.Error("Failed to create DM channel with user: " + err.Error())
+	} else {
+		// Send the embed to the user
+		_, err = state.SendMessage(dmChannel.ID, "", embed)
+		if err != nil {
+			logger.Error("Failed to send close notification to user: " + err.Error())
+		}
+	}
+
+	// Post the transcript to the log channel before the channel and its history are gone
+	if record != nil {
+		if err := ArchiveTicket(config, state, store, record); err != nil {
+			logger.Error("Failed to archive ticket: " + err.Error())
+		}
+	}
+
+	// Log the ticket closure
+	logger.Info("Ticket closed by staff member " + options.Closer.ID.String())
+
+	// Delete the channel with audit log reason
+	auditLogReason := "Ticket closed by " + options.Closer.Tag()
+	if options.Reason != "" {
+		auditLogReason += ": " + options.Reason
+	}
+
+	return state.DeleteChannel(channel.ID, api.AuditLogReason(auditLogReason))
+}
+
+// ScheduleClose schedules a ticket to be closed after the given delay and warns the ticket owner.
+// The close is cancelled if the user replies before then.
+//
+// Returns: an error if any
+func ScheduleClose(state *state.State, store database.Store, ticket *Ticket, delay time.Duration, options CloseOptions) error {
+	err := store.ScheduledCloses().Schedule(&database.ScheduledClose{
+		TicketID:    ticket.Record.ID,
+		CloseAt:     time.Now().Add(delay),
+		ScheduledBy: options.Closer.ID,
+		Reason:      options.Reason,
+		Silent:      options.Silent,
+	})
+	if err != nil {
+		return err
+	}
+
+	embed := discord.Embed{
+		Title:       language.GetTranslation("embeds.close_scheduled.title"),
+		Description: fmt.Sprintf(language.GetTranslation("embeds.close_scheduled.description"), duration.Format(delay)),
+		Color:       colors.GetColor(colors.Orange),
+		Timestamp:   discord.NowTimestamp(),
+		Footer: &discord.EmbedFooter{
+			Text: "ModMail",
+		},
+	}
+
+	dmChannel, err := state.CreatePrivateChannel(ticket.Author.ID)
+	if err != nil {
+		logger.Error("Failed to create DM channel with user: " + err.Error())
+		return nil
+	}
+
+	if _, err = state.SendEmbeds(dmChannel.ID, embed); err != nil {
+		logger.Error("Failed to send close warning to user: " + err.Error())
+	}
+
+	return nil
+}
+
+// CancelScheduledClose cancels the scheduled close of a ticket
+//
+// Returns: whether a close was scheduled and an error if any
+func CancelScheduledClose(store database.Store, ticket *Ticket) (bool, error) {
+	return store.ScheduledCloses().Cancel(ticket.Record.ID)
+}
+
+// WarnInactivity tells the owner of an idle ticket, and the staff in its channel,
+// that the ticket will be closed unless somebody replies
+//
+// Returns: an error if any
+func WarnInactivity(state *state.State, store database.Store, record *database.Ticket, idle, remaining time.Duration) error {
+	if err := store.Tickets().MarkInactivityWarned(record.ID, time.Now()); err != nil {
+		return err
+	}
+
+	embed := discord.Embed{
+		Title:       language.GetTranslation("embeds.inactivity_warning.title"),
+		Description: fmt.Sprintf(language.GetTranslation("embeds.inactivity_warning.description"), duration.Format(idle), duration.Format(remaining)),
+		Color:       colors.GetColor(colors.Orange),
+		Timestamp:   discord.NowTimestamp(),
+		Footer: &discord.EmbedFooter{
+			Text: "ModMail",
+		},
+	}
+
+	dmChannel, err := state.CreatePrivateChannel(record.UserID)
+	if err != nil {
+		logger.Error("Failed to create DM channel with user: " + err.Error())
+	} else if _, err = state.SendEmbeds(dmChannel.ID, embed); err != nil {
+		logger.Error("Failed to send inactivity warning to user: " + err.Error())
+	}
+
+	notice := fmt.Sprintf(language.GetTranslation("tickets.inactivity.warned"), duration.Format(idle), duration.Format(remaining))
+	if _, err := state.SendMessage(record.ChannelID, notice); err != nil {
+		logger.Error("Failed to post inactivity notice in ticket channel: " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package tickets
+
+import (
+	"context"
+	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+)
+
+// contextKey is the key the ticket of a command is stored under in its context
+type contextKey struct{}
+
+// NewContext returns a copy of the context that carries the given ticket
+func NewContext(ctx context.Context, ticket *Ticket) context.Context {
+	return context.WithValue(ctx, contextKey{}, ticket)
+}
+
+// FromContext gets the ticket stored in the context by NewContext
+//
+// Returns: a pointer to a Ticket, nil if the context carries none
+func FromContext(ctx context.Context) *Ticket {
+	ticket, _ := ctx.Value(contextKey{}).(*Ticket)
+	return ticket
+}
+
+// FindChannelTicket finds the open ticket a channel belongs to, using the owner in the channel topic
+//
+// Returns: a pointer to a Ticket, nil if the channel is not an open ticket, and an error if any
+func FindChannelTicket(config *config.Config, state *state.State, store database.Store, channelID discord.ChannelID) (*Ticket, error) {
+	channel, err := state.Channel(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := GetAuthorFromChannel(state, channel)
+	if err != nil || owner == nil {
+		return nil, err
+	}
+
+	ticket, err := GetActiveTicket(config, state, store, owner)
+	if err != nil || ticket == nil {
+		return nil, err
+	}
+
+	// An old channel of the user can still carry their ID in its topic
+	if ticket.Channel.ID != channelID {
+		return nil, nil
+	}
+
+	return ticket, nil
+}
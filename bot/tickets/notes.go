@@ -0,0 +1,50 @@
+package tickets
+
+import (
+	"discord-bot-tickets/bot/commands/helpers/colors"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/database"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+)
+
+// AddNote posts an internal note in the ticket channel and stores it in the
+// history of the ticket. Notes are never sent to the user.
+//
+// Returns: a pointer to the stored note and an error if any
+func AddNote(state *state.State, store database.Store, record *database.Ticket, author discord.User, content string) (*database.TicketMessage, error) {
+	embed := discord.Embed{
+		Title:       language.GetTranslation("embeds.note.title"),
+		Description: content,
+		Color:       colors.GetColor(colors.Purple),
+		Author: &discord.EmbedAuthor{
+			Name: author.Username,
+			Icon: author.AvatarURL(),
+		},
+		Timestamp: discord.NowTimestamp(),
+		Footer: &discord.EmbedFooter{
+			Text: "ModMail • " + language.GetTranslation("embeds.note.footer"),
+		},
+	}
+
+	message, err := state.SendEmbeds(record.ChannelID, embed)
+	if err != nil {
+		return nil, err
+	}
+
+	note := &database.TicketMessage{
+		TicketID:   record.ID,
+		MessageID:  message.ID,
+		AuthorID:   author.ID,
+		AuthorName: author.Username,
+		Direction:  database.MessageInternal,
+		Content:    content,
+	}
+
+	if err := store.Messages().Create(note); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
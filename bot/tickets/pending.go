@@ -0,0 +1,318 @@
+package tickets
+
+import (
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/commands/helpers/messages"
+	"discord-bot-tickets/config"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// TicketTypeComponentID is the custom ID of the select menu users choose the type of their ticket with
+const TicketTypeComponentID = "ticket_type"
+
+// TicketConfirmComponentID is the custom ID of the button users confirm opening a ticket with
+const TicketConfirmComponentID = "ticket_confirm"
+
+// TicketCancelComponentID is the custom ID of the button users cancel opening a ticket with
+const TicketCancelComponentID = "ticket_cancel"
+
+// maxSelectLength is the number of characters Discord allows in the label and description of a select option
+const maxSelectLength = 100
+
+// pendingTicket holds the messages a user sent before their ticket was opened
+type pendingTicket struct {
+	messages []discord.Message
+	since    time.Time
+	// prompt is the message asking the user to confirm or choose a ticket type
+	prompt discord.MessageReference
+	// opening is set once the user answered, so the prompt no longer expires
+	opening bool
+}
+
+// PendingTickets stores the messages of users who haven't confirmed or chosen the type of their ticket yet
+type PendingTickets struct {
+	tickets map[discord.UserID]*pendingTicket
+	mu      sync.Mutex
+}
+
+var pendingTickets = &PendingTickets{
+	tickets: make(map[discord.UserID]*pendingTicket),
+}
+
+// Hold keeps a message until the ticket of its author is opened
+//
+// Returns: true if it is the first message held for the author
+func (p *PendingTickets) Hold(message discord.Message) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pending, ok := p.tickets[message.Author.ID]
+	if !ok {
+		p.tickets[message.Author.ID] = &pendingTicket{
+			messages: []discord.Message{message},
+			since:    time.Now(),
+		}
+		return true
+	}
+
+	pending.messages = append(pending.messages, message)
+	return false
+}
+
+// SetPrompt remembers the prompt a pending user was sent, so it can be updated when it expires
+func (p *PendingTickets) SetPrompt(userID discord.UserID, prompt discord.MessageReference) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if pending, ok := p.tickets[userID]; ok {
+		pending.prompt = prompt
+	}
+}
+
+// Refresh restarts the timeout of the prompt of a pending user, after they answered with a follow-up prompt
+//
+// Returns: false if the user has no messages waiting for their answer
+func (p *PendingTickets) Refresh(userID discord.UserID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pending, ok := p.tickets[userID]
+	if !ok || pending.opening || len(pending.messages) == 0 {
+		return false
+	}
+
+	pending.since = time.Now()
+	return true
+}
+
+// Take removes the held messages of a user, the user stays pending until Release is called
+//
+// Returns: the held messages, or nil if the user has none or is already being taken care of
+func (p *PendingTickets) Take(userID discord.UserID) []discord.Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pending, ok := p.tickets[userID]
+	if !ok || pending.opening {
+		return nil
+	}
+
+	held := pending.messages
+	pending.messages = nil
+	pending.opening = true
+
+	return held
+}
+
+// Release forgets a pending user, unless more messages were held since the last Take
+//
+// Returns: the messages held since the last Take
+func (p *PendingTickets) Release(userID discord.UserID) []discord.Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pending, ok := p.tickets[userID]
+	if !ok {
+		return nil
+	}
+
+	if len(pending.messages) == 0 {
+		delete(p.tickets, userID)
+		return nil
+	}
+
+	held := pending.messages
+	pending.messages = nil
+
+	return held
+}
+
+// Forget removes a pending user and any messages held for them
+func (p *PendingTickets) Forget(userID discord.UserID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.tickets, userID)
+}
+
+// Expire removes the users who were prompted before the given time and haven't answered
+//
+// Returns: the prompts of the removed users
+func (p *PendingTickets) Expire(before time.Time) []discord.MessageReference {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var prompts []discord.MessageReference
+	for userID, pending := range p.tickets {
+		if pending.opening || !pending.since.Before(before) {
+			continue
+		}
+
+		if pending.prompt.MessageID.IsValid() {
+			prompts = append(prompts, pending.prompt)
+		}
+		delete(p.tickets, userID)
+	}
+
+	return prompts
+}
+
+// HoldMessage keeps a message sent before its author confirmed or chose the type of their ticket
+//
+// Returns: true if it is the first message, so the user still has to be prompted
+func HoldMessage(message discord.Message) bool {
+	return pendingTickets.Hold(message)
+}
+
+// RefreshPendingMessages gives a user who confirmed their ticket the full timeout to choose its type
+//
+// Returns: false if the user has no messages waiting for their answer
+func RefreshPendingMessages(userID discord.UserID) bool {
+	return pendingTickets.Refresh(userID)
+}
+
+// TakePendingMessages removes the messages held for a user so their ticket can be opened
+//
+// Returns: the held messages, or nil if the user has none or their ticket is already being opened
+func TakePendingMessages(userID discord.UserID) []discord.Message {
+	return pendingTickets.Take(userID)
+}
+
+// ForgetPendingMessages drops the messages held for a user, so their next message starts over
+func ForgetPendingMessages(userID discord.UserID) {
+	pendingTickets.Forget(userID)
+}
+
+// PromptNewTicket replies to the first message of a user without a ticket. With confirmation
+// enabled the user is asked to confirm first, otherwise to choose the type of their ticket.
+//
+// Returns: an error if any
+func PromptNewTicket(config *config.Config, state *state.State, message discord.Message) error {
+	data := api.SendMessageData{
+		Content:    language.GetTranslation("tickets.types.prompt"),
+		Components: TicketTypeMenu(config),
+		Reference:  &discord.MessageReference{MessageID: message.ID},
+	}
+
+	if config.Confirmation.Enabled {
+		data.Content = language.GetTranslation("tickets.confirm.prompt")
+		data.Components = ConfirmButtons()
+	}
+
+	prompt, err := state.SendMessageComplex(message.ChannelID, data)
+	if err != nil {
+		return err
+	}
+
+	pendingTickets.SetPrompt(message.Author.ID, discord.MessageReference{
+		ChannelID: prompt.ChannelID,
+		MessageID: prompt.ID,
+	})
+
+	return nil
+}
+
+// TicketTypeMenu builds the select menu of the configured ticket types
+func TicketTypeMenu(config *config.Config) discord.ContainerComponents {
+	options := make([]discord.SelectOption, 0, len(config.TicketTypes))
+	for _, ticketType := range config.TicketTypes {
+		options = append(options, discord.SelectOption{
+			Label:       truncate(ticketType.Name, maxSelectLength),
+			Value:       ticketType.Key,
+			Description: truncate(ticketType.Description, maxSelectLength),
+		})
+	}
+
+	return discord.Components(&discord.StringSelectComponent{
+		CustomID:    TicketTypeComponentID,
+		Placeholder: language.GetTranslation("tickets.types.placeholder"),
+		Options:     options,
+	})
+}
+
+// ConfirmButtons builds the buttons users confirm or cancel opening a ticket with
+func ConfirmButtons() discord.ContainerComponents {
+	return discord.Components(
+		&discord.ButtonComponent{
+			Style:    discord.SuccessButtonStyle(),
+			CustomID: TicketConfirmComponentID,
+			Label:    language.GetTranslation("tickets.confirm.confirm"),
+		},
+		&discord.ButtonComponent{
+			Style:    discord.DangerButtonStyle(),
+			CustomID: TicketCancelComponentID,
+			Label:    language.GetTranslation("tickets.confirm.cancel"),
+		},
+	)
+}
+
+// ExpirePrompts drops the messages of users who didn't answer their prompt within the
+// timeout, and removes the buttons or menu from the prompt so it can't be answered anymore
+//
+// Returns: the number of expired prompts
+func ExpirePrompts(state *state.State, timeout time.Duration) int {
+	prompts := pendingTickets.Expire(time.Now().Add(-timeout))
+
+	for _, prompt := range prompts {
+		_, err := state.EditMessageComplex(prompt.ChannelID, prompt.MessageID, api.EditMessageData{
+			Content:    option.NewNullableString(language.GetTranslation("tickets.confirm.expired")),
+			Components: &discord.ContainerComponents{},
+		})
+		if err != nil {
+			logger.Warn("Failed to mark prompt %s as expired: %v", prompt.MessageID, err)
+		}
+	}
+
+	return len(prompts)
+}
+
+// OpenPendingTicket opens a ticket with the messages a user sent while they were prompted.
+// The first message opens the ticket and the rest are relayed after it, including any
+// sent while the ticket was being opened. The ticket type is nil if there are no types.
+//
+// Returns: an error if any
+func OpenPendingTicket(config *config.Config, state *state.State, store database.Store, author discord.User, held []discord.Message, ticketType *config.TicketType) error {
+	if len(held) == 0 {
+		pendingTickets.Forget(author.ID)
+		return nil
+	}
+
+	if _, err := CreateTicket(config, state, store, author, held[0], ticketType); err != nil {
+		pendingTickets.Forget(author.ID)
+		return err
+	}
+
+	reactRelayed(state, held[0])
+
+	// Later messages are relayed by the listener once the ticket is cached, the ones
+	// that arrived in between are still held and are relayed here
+	rest := held[1:]
+	for {
+		for _, message := range rest {
+			if err := UpdateTicket(config, state, store, author, RegularMessage{Message: message}); err != nil {
+				pendingTickets.Forget(author.ID)
+				return err
+			}
+
+			reactRelayed(state, message)
+		}
+
+		if rest = pendingTickets.Release(author.ID); len(rest) == 0 {
+			return nil
+		}
+	}
+}
+
+// reactRelayed marks a message as delivered to staff
+func reactRelayed(state *state.State, message discord.Message) {
+	if err := messages.ReactToMessage(state, message, "✅"); err != nil {
+		logger.Error(err.Error())
+	}
+}
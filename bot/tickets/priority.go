@@ -0,0 +1,82 @@
+package tickets
+
+import (
+	"context"
+	"discord-bot-tickets/bot/commands/helpers/colors"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+)
+
+// priorityPrefixes are put in front of the ticket channel name, normal tickets keep their plain name
+var priorityPrefixes = map[database.TicketPriority]string{
+	database.PriorityLow:    "🔵",
+	database.PriorityHigh:   "🟠",
+	database.PriorityUrgent: "🔴",
+}
+
+// priorityColors color the messages of the user in the ticket channel
+var priorityColors = map[database.TicketPriority]colors.ColorName{
+	database.PriorityLow:    colors.Blue,
+	database.PriorityNormal: colors.Yellow,
+	database.PriorityHigh:   colors.Orange,
+	database.PriorityUrgent: colors.Red,
+}
+
+// SetPriority changes the priority of a ticket and renames its channel to match
+//
+// Returns: an error if any
+func SetPriority(state *state.State, store database.Store, record *database.Ticket, priority database.TicketPriority) error {
+	if err := store.Tickets().SetPriority(record.ID, priority); err != nil {
+		return err
+	}
+
+	record.Priority = priority
+
+	if ticket := ticketCache.GetTicket(record.UserID); ticket != nil && ticket.Record != nil && ticket.Record.ID == record.ID {
+		ticket.Record.Priority = priority
+	}
+
+	channel, err := state.Channel(record.ChannelID)
+	if err != nil {
+		logger.Warn("Failed to get the channel of ticket %d: %v", record.ID, err)
+		return nil
+	}
+
+	name := priorityPrefixes[priority] + stripPriorityPrefix(channel.Name)
+	if name == channel.Name {
+		return nil
+	}
+
+	// Channel names share the rate limit of topics, so a busy channel isn't waited on
+	ctx, cancel := context.WithTimeout(context.Background(), topicTimeout)
+	defer cancel()
+
+	if err := state.WithContext(ctx).ModifyChannel(record.ChannelID, api.ModifyChannelData{Name: name}); err != nil {
+		logger.Warn("Failed to rename the channel of ticket %d: %v", record.ID, err)
+	}
+
+	return nil
+}
+
+// PriorityColor gets the embed color of a ticket priority
+func PriorityColor(priority database.TicketPriority) discord.Color {
+	if name, ok := priorityColors[priority]; ok {
+		return colors.GetColor(name)
+	}
+
+	return colors.GetColor(colors.Yellow)
+}
+
+// stripPriorityPrefix removes the priority prefix from a channel name
+func stripPriorityPrefix(name string) string {
+	for _, prefix := range priorityPrefixes {
+		name = strings.TrimPrefix(name, prefix)
+	}
+
+	return name
+}
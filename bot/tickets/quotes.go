@@ -0,0 +1,100 @@
+package tickets
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// maxQuoteLength keeps a quote short enough to leave room for the reply itself
+const maxQuoteLength = 1000
+
+// quoteKey identifies the staff member replying and the owner of the ticket they reply to
+type quoteKey struct {
+	Owner discord.UserID
+	Staff discord.UserID
+}
+
+// Quotes stores the messages staff chose to quote in their next reply to a ticket
+type Quotes struct {
+	quotes map[quoteKey]string
+	mu     sync.Mutex
+}
+
+var quotes = &Quotes{
+	quotes: make(map[quoteKey]string),
+}
+
+// Set remembers the text a staff member quotes in their next reply, replacing an earlier quote
+func (q *Quotes) Set(ownerID discord.UserID, staffID discord.UserID, text string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.quotes[quoteKey{Owner: ownerID, Staff: staffID}] = text
+}
+
+// Take gets the text a staff member quotes and forgets it, since it is only used once
+//
+// Returns: the quoted text, or an empty string if there is none
+func (q *Quotes) Take(ownerID discord.UserID, staffID discord.UserID) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := quoteKey{Owner: ownerID, Staff: staffID}
+	text := q.quotes[key]
+	delete(q.quotes, key)
+
+	return text
+}
+
+// Forget drops every quote for the ticket of a user, once it is closed
+func (q *Quotes) Forget(ownerID discord.UserID) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for key := range q.quotes {
+		if key.Owner == ownerID {
+			delete(q.quotes, key)
+		}
+	}
+}
+
+// SetQuote remembers the text a staff member quotes in their next reply to the ticket of a user
+func SetQuote(ownerID discord.UserID, staffID discord.UserID, text string) {
+	quotes.Set(ownerID, staffID, truncate(text, maxQuoteLength))
+}
+
+// WithQuote puts the text a staff member chose to quote above their reply, if any
+//
+// Returns: the reply with the quote
+func WithQuote(ownerID discord.UserID, staffID discord.UserID, reply string) string {
+	text := quotes.Take(ownerID, staffID)
+	if text == "" {
+		return reply
+	}
+
+	return QuoteText(text) + "\n" + reply
+}
+
+// QuoteText turns text into a Markdown block quote
+func QuoteText(text string) string {
+	return "> " + strings.ReplaceAll(text, "\n", "\n> ")
+}
+
+// QuotedContent gets the text of a message to quote. That is its content, or for a message
+// the bot relayed the descriptions of its embeds, which hold the relayed content.
+func QuotedContent(message discord.Message) string {
+	if message.Content != "" {
+		return message.Content
+	}
+
+	var parts []string
+	for _, embed := range message.Embeds {
+		if embed.Description != "" {
+			parts = append(parts, embed.Description)
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,296 @@
+package tickets
+
+import (
+	"bytes"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/bot/commands/helpers/messages"
+	logger "discord-bot-tickets/logging"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+)
+
+// maxUploadSize is the largest attachment the bot re-uploads, anything bigger is linked instead
+const maxUploadSize = 10 * 1024 * 1024
+
+// maxEmbeds is the number of embeds Discord allows on a single message
+const maxEmbeds = 10
+
+// maxDescriptionLength is the number of characters Discord allows in an embed description
+const maxDescriptionLength = 4096
+
+// maxMessageEmbedLength is the number of characters Discord allows across all embeds of a message
+const maxMessageEmbedLength = 6000
+
+// stickerFormatGIF is the sticker format type of animated GIF stickers
+const stickerFormatGIF discord.StickerFormatType = 4
+
+var downloadClient = &http.Client{Timeout: 30 * time.Second}
+
+// relayedFile is an attachment downloaded once, so it can be uploaded to both sides of a ticket
+type relayedFile struct {
+	Name  string
+	Data  []byte
+	Image bool
+}
+
+// relay holds everything besides the text that is mirrored along with a message
+type relay struct {
+	files    []relayedFile
+	links    []discord.Attachment
+	stickers []discord.StickerItem
+	embeds   []discord.Embed
+}
+
+// newRelay downloads the attachments of a message and collects its stickers and embeds.
+// Attachments that are too big or fail to download are linked instead.
+//
+// Returns: a pointer to a relay
+func newRelay(message MessageContent) *relay {
+	r := &relay{
+		stickers: message.GetStickers(),
+	}
+
+	for _, attachment := range message.GetAttachments() {
+		if attachment.Size > maxUploadSize {
+			r.links = append(r.links, attachment)
+			continue
+		}
+
+		data, err := download(attachment.URL)
+		if err != nil {
+			logger.Warn("Failed to download attachment %s, linking it instead: %v", attachment.Filename, err)
+			r.links = append(r.links, attachment)
+			continue
+		}
+
+		r.files = append(r.files, relayedFile{
+			Name:  attachment.Filename,
+			Data:  data,
+			Image: isImage(attachment),
+		})
+	}
+
+	// Only rich embeds are forwarded, link previews are rebuilt from the text by Discord
+	for _, embed := range message.GetEmbeds() {
+		if embed.Type == discord.NormalEmbed && len(r.embeds) < maxEmbeds-1 {
+			r.embeds = append(r.embeds, embed)
+		}
+	}
+
+	return r
+}
+
+// send sends the embed of a relayed message to a channel, together with its
+// attachments, stickers and forwarded embeds. The content goes into the embed
+// description and is split across several embeds, and if needed several
+// messages, when it is too long for one.
+//
+// Returns: the sent messages and an error if any
+func (r *relay) send(state *state.State, channelID discord.ChannelID, embed discord.Embed, content string) ([]discord.Message, error) {
+	files := make([]sendpart.File, 0, len(r.files))
+	for _, file := range r.files {
+		part := sendpart.File{Name: file.Name, Reader: bytes.NewReader(file.Data)}
+
+		// Show the first image inside the embed, the rest are shown below it
+		if file.Image && embed.Image == nil {
+			embed.Image = &discord.EmbedImage{URL: part.AttachmentURI()}
+		}
+
+		files = append(files, part)
+	}
+
+	for _, link := range r.links {
+		if isImage(link) && embed.Image == nil {
+			embed.Image = &discord.EmbedImage{URL: link.URL}
+		}
+	}
+
+	if len(r.links) > 0 {
+		lines := make([]string, 0, len(r.links))
+		for _, link := range r.links {
+			lines = append(lines, fmt.Sprintf("[%s](%s)", link.Filename, link.URL))
+		}
+
+		for _, value := range joinFields(lines) {
+			embed.Fields = append(embed.Fields, discord.EmbedField{
+				Name:  language.GetTranslation("embeds.relay.attachments"),
+				Value: value,
+			})
+		}
+	}
+
+	if len(r.stickers) > 0 {
+		names := make([]string, 0, len(r.stickers))
+		for _, sticker := range r.stickers {
+			names = append(names, sticker.Name)
+
+			if url := stickerURL(sticker); url != "" && embed.Image == nil {
+				embed.Image = &discord.EmbedImage{URL: url}
+			}
+		}
+
+		embed.Fields = append(embed.Fields, discord.EmbedField{
+			Name:  language.GetTranslation("embeds.relay.stickers"),
+			Value: strings.Join(names, ", "),
+		})
+	}
+
+	embeds := []discord.Embed{embed}
+	for i, chunk := range messages.Split(content, maxDescriptionLength) {
+		if i == 0 {
+			embeds[0].Description = chunk
+			continue
+		}
+
+		embeds = append(embeds, discord.Embed{
+			Color:       embed.Color,
+			Description: chunk,
+		})
+	}
+	embeds = append(embeds, r.embeds...)
+
+	var sent []discord.Message
+	for i, batch := range batchEmbeds(embeds) {
+		data := api.SendMessageData{Embeds: batch}
+		// The files only go with the first message, where the embed points at them
+		if i == 0 {
+			data.Files = files
+		}
+
+		message, err := state.SendMessageComplex(channelID, data)
+		if err != nil {
+			return sent, err
+		}
+
+		sent = append(sent, *message)
+	}
+
+	return sent, nil
+}
+
+// joinFields joins lines into as few embed field values as Discord's field limit allows.
+// A line that is too long for a field on its own is cut short.
+//
+// Returns: the field values
+func joinFields(lines []string) []string {
+	var (
+		values  []string
+		current []string
+		length  int
+	)
+
+	for _, line := range lines {
+		line = truncate(line, maxFieldLength)
+		lineLength := utf8.RuneCountInString(line)
+
+		// The newline joining it to the previous line counts as well
+		if len(current) > 0 && length+1+lineLength > maxFieldLength {
+			values = append(values, strings.Join(current, "\n"))
+			current, length = nil, 0
+		}
+
+		if len(current) > 0 {
+			length++
+		}
+		current = append(current, line)
+		length += lineLength
+	}
+
+	if len(current) > 0 {
+		values = append(values, strings.Join(current, "\n"))
+	}
+
+	return values
+}
+
+// batchEmbeds groups embeds into as few messages as Discord's limits allow
+//
+// Returns: a slice of embeds for each message
+func batchEmbeds(embeds []discord.Embed) [][]discord.Embed {
+	var (
+		batches [][]discord.Embed
+		current []discord.Embed
+		length  int
+	)
+
+	for _, embed := range embeds {
+		size := embedLength(embed)
+		if len(current) > 0 && (len(current) == maxEmbeds || length+size > maxMessageEmbedLength) {
+			batches = append(batches, current)
+			current, length = nil, 0
+		}
+
+		current = append(current, embed)
+		length += size
+	}
+
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+
+	return batches
+}
+
+// embedLength counts the characters of an embed the way Discord does for its limits
+func embedLength(embed discord.Embed) int {
+	length := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+
+	if embed.Author != nil {
+		length += utf8.RuneCountInString(embed.Author.Name)
+	}
+
+	if embed.Footer != nil {
+		length += utf8.RuneCountInString(embed.Footer.Text)
+	}
+
+	for _, field := range embed.Fields {
+		length += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+
+	return length
+}
+
+// stickerURL gets the image URL of a sticker
+//
+// Returns: the URL, or an empty string for Lottie stickers, which can't be shown as an image
+func stickerURL(sticker discord.StickerItem) string {
+	switch sticker.FormatType {
+	case discord.StickerFormatLottie:
+		return ""
+	case stickerFormatGIF:
+		return sticker.StickerURLWithType(discord.GIFImage)
+	default:
+		return sticker.StickerURLWithType(discord.PNGImage)
+	}
+}
+
+// isImage checks if an attachment can be previewed inside an embed
+func isImage(attachment discord.Attachment) bool {
+	return strings.HasPrefix(attachment.ContentType, "image/")
+}
+
+// download fetches the content of an attachment
+//
+// Returns: the content and an error if any
+func download(url string) ([]byte, error) {
+	resp, err := downloadClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxUploadSize+1))
+}
@@ -0,0 +1,95 @@
+package tickets
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestJoinFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		// want is the length of every field value, in characters
+		want []int
+	}{
+		{name: "no attachments", lines: nil, want: nil},
+		{name: "single link", lines: []string{strings.Repeat("a", 100)}, want: []int{100}},
+		{name: "exactly at the field limit", lines: []string{strings.Repeat("a", 511), strings.Repeat("b", 512)}, want: []int{maxFieldLength}},
+		{name: "one over the field limit", lines: []string{strings.Repeat("a", 512), strings.Repeat("b", 512)}, want: []int{512, 512}},
+		{name: "single link at the field limit", lines: []string{strings.Repeat("a", maxFieldLength)}, want: []int{maxFieldLength}},
+		{name: "single link longer than the field limit", lines: []string{strings.Repeat("a", 2000)}, want: []int{maxFieldLength}},
+		{name: "long link between short ones", lines: []string{"a", strings.Repeat("b", 2000), "c"}, want: []int{1, maxFieldLength, 1}},
+		{name: "limit counted in characters", lines: []string{strings.Repeat("é", 511), strings.Repeat("ü", 512)}, want: []int{maxFieldLength}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := joinFields(tt.lines)
+
+			var got []int
+			for _, value := range values {
+				got = append(got, utf8.RuneCountInString(value))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("field lengths = %v, want %v", got, tt.want)
+			}
+
+			// Nothing is lost besides the end of lines that are too long
+			var joined []string
+			for _, line := range tt.lines {
+				joined = append(joined, truncate(line, maxFieldLength))
+			}
+			if strings.Join(values, "\n") != strings.Join(joined, "\n") {
+				t.Errorf("fields do not hold every line in order")
+			}
+		})
+	}
+}
+
+func TestBatchEmbeds(t *testing.T) {
+	// description is an embed with a description of the given length
+	description := func(length int) discord.Embed {
+		return discord.Embed{Description: strings.Repeat("a", length)}
+	}
+
+	tests := []struct {
+		name   string
+		embeds []discord.Embed
+		// want is the number of embeds in every message
+		want []int
+	}{
+		{name: "no embeds", embeds: nil, want: nil},
+		{name: "single embed", embeds: []discord.Embed{description(10)}, want: []int{1}},
+		{name: "exactly at the message limit", embeds: []discord.Embed{description(3000), description(3000)}, want: []int{2}},
+		{name: "one over the message limit", embeds: []discord.Embed{description(3000), description(3001)}, want: []int{1, 1}},
+		{
+			name: "fields and footer count towards the limit",
+			embeds: []discord.Embed{
+				{Description: strings.Repeat("a", 4000), Footer: &discord.EmbedFooter{Text: "ModMail"}, Fields: []discord.EmbedField{{Name: "Attachments", Value: strings.Repeat("b", 1000)}}},
+				description(1000),
+			},
+			want: []int{1, 1},
+		},
+		{name: "exactly at the embed count limit", embeds: slices.Repeat([]discord.Embed{description(1)}, maxEmbeds), want: []int{maxEmbeds}},
+		{name: "one over the embed count limit", embeds: slices.Repeat([]discord.Embed{description(1)}, maxEmbeds+1), want: []int{maxEmbeds, 1}},
+		{name: "embed longer than the message limit", embeds: []discord.Embed{description(1), description(maxMessageEmbedLength + 1), description(1)}, want: []int{1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := batchEmbeds(tt.embeds)
+
+			var got []int
+			for _, batch := range batches {
+				got = append(got, len(batch))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("embeds per message = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
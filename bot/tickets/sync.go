@@ -0,0 +1,229 @@
+package tickets
+
+import (
+	"discord-bot-tickets/bot/commands/helpers/colors"
+	"discord-bot-tickets/bot/commands/helpers/language"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+)
+
+// maxFieldLength is the number of characters Discord allows in an embed field value
+const maxFieldLength = 1024
+
+// SyncEdit applies an edit of a relayed user message to its copies in the ticket channel.
+// The copy shows the new content and keeps the original for staff.
+//
+// Returns: an error if any
+func SyncEdit(state *state.State, store database.Store, messageID discord.MessageID, content string) error {
+	entry, err := store.Messages().FindByMessageID(messageID)
+	if err != nil || entry == nil || entry.Content == content {
+		return err
+	}
+
+	if err := store.Messages().Edit(entry.ID, content, time.Now()); err != nil {
+		return err
+	}
+
+	copies, err := store.RelayedMessages().ListBySource(messageID)
+	if err != nil || len(copies) == 0 {
+		return err
+	}
+
+	// The content of a user message always fits in the embed of the first copy
+	first := copies[0]
+
+	relayed, err := state.Message(first.ChannelID, first.MessageID)
+	if err != nil {
+		return err
+	}
+
+	if len(relayed.Embeds) == 0 {
+		return nil
+	}
+
+	embed := relayed.Embeds[0]
+	embed.Description = content
+
+	// Only the first edit adds the original, later edits keep showing it
+	originalName := language.GetTranslation("embeds.relay.original")
+	if !hasField(embed, originalName) && entry.Content != "" {
+		embed.Fields = append(embed.Fields, discord.EmbedField{
+			Name:  originalName,
+			Value: truncate(entry.Content, maxFieldLength),
+		})
+	}
+
+	markFooter(&embed, language.GetTranslation("embeds.relay.edited"))
+
+	relayed.Embeds[0] = embed
+	_, err = state.EditEmbeds(first.ChannelID, first.MessageID, relayed.Embeds...)
+
+	return err
+}
+
+// SyncDelete applies the deletion of a relayed message to its copies. Copies of a
+// deleted user message are kept for staff and marked as deleted, while a deleted
+// ticket channel copy of a staff reply removes the reply from the user's DMs too.
+//
+// Returns: an error if any
+func SyncDelete(state *state.State, store database.Store, messageID discord.MessageID) error {
+	entry, err := store.Messages().FindByMessageID(messageID)
+	if err != nil || entry == nil || entry.DeletedAt != nil {
+		return err
+	}
+
+	if err := store.Messages().MarkDeleted(entry.ID, time.Now()); err != nil {
+		return err
+	}
+
+	copies, err := store.RelayedMessages().ListBySource(messageID)
+	if err != nil {
+		return err
+	}
+
+	if entry.Direction == database.MessageOutbound {
+		return deleteCopies(state, store, messageID, copies)
+	}
+
+	for _, copied := range copies {
+		relayed, err := state.Message(copied.ChannelID, copied.MessageID)
+		if err != nil {
+			logger.Error("Failed to fetch relayed copy of message %s: %v", messageID, err)
+			continue
+		}
+
+		if len(relayed.Embeds) == 0 {
+			continue
+		}
+
+		relayed.Embeds[0].Color = colors.GetColor(colors.Red)
+		markFooter(&relayed.Embeds[0], language.GetTranslation("embeds.relay.deleted"))
+
+		if _, err := state.EditEmbeds(copied.ChannelID, copied.MessageID, relayed.Embeds...); err != nil {
+			logger.Error("Failed to mark relayed copy of message %s as deleted: %v", messageID, err)
+		}
+	}
+
+	return nil
+}
+
+// EditReply changes the content of a staff reply, both in the ticket channel and in the user's DMs
+//
+// Returns: an error if any
+func EditReply(state *state.State, store database.Store, entry *database.TicketMessage, content string) error {
+	record, err := store.Tickets().FindByID(entry.TicketID)
+	if err != nil {
+		return err
+	}
+
+	copies, err := store.RelayedMessages().ListBySource(entry.MessageID)
+	if err != nil {
+		return err
+	}
+
+	// The ticket channel copy is the source of a staff reply, so it is not among its copies
+	copies = append([]database.RelayedMessage{{ChannelID: record.ChannelID, MessageID: entry.MessageID}}, copies...)
+
+	for _, copied := range copies {
+		relayed, err := state.Message(copied.ChannelID, copied.MessageID)
+		if err != nil {
+			return err
+		}
+
+		// A reply that was too long for one message continues in messages without an author,
+		// the edited content fits in the first message so those are removed
+		if len(relayed.Embeds) == 0 || relayed.Embeds[0].Author == nil {
+			if err := state.DeleteMessage(copied.ChannelID, copied.MessageID, "Relayed reply was edited"); err != nil {
+				logger.Error("Failed to delete continuation of reply %s: %v", entry.MessageID, err)
+			}
+			if err := store.RelayedMessages().DeleteByMessage(copied.MessageID); err != nil {
+				logger.Error("Failed to forget continuation of reply %s: %v", entry.MessageID, err)
+			}
+			continue
+		}
+
+		embed := relayed.Embeds[0]
+		embed.Description = content
+		markFooter(&embed, language.GetTranslation("embeds.relay.edited"))
+
+		if _, err := state.EditEmbeds(copied.ChannelID, copied.MessageID, embed); err != nil {
+			return err
+		}
+	}
+
+	return store.Messages().Edit(entry.ID, content, time.Now())
+}
+
+// DeleteReply deletes a staff reply, both from the ticket channel and from the user's DMs
+//
+// Returns: an error if any
+func DeleteReply(state *state.State, store database.Store, entry *database.TicketMessage) error {
+	record, err := store.Tickets().FindByID(entry.TicketID)
+	if err != nil {
+		return err
+	}
+
+	// Mark the reply first, so the delete event of the ticket channel copy is ignored
+	if err := store.Messages().MarkDeleted(entry.ID, time.Now()); err != nil {
+		return err
+	}
+
+	copies, err := store.RelayedMessages().ListBySource(entry.MessageID)
+	if err != nil {
+		return err
+	}
+
+	copies = append(copies, database.RelayedMessage{ChannelID: record.ChannelID, MessageID: entry.MessageID})
+
+	return deleteCopies(state, store, entry.MessageID, copies)
+}
+
+// deleteCopies deletes the copies of a source message and forgets about them
+//
+// Returns: an error if any
+func deleteCopies(state *state.State, store database.Store, sourceID discord.MessageID, copies []database.RelayedMessage) error {
+	for _, relayed := range copies {
+		if err := state.DeleteMessage(relayed.ChannelID, relayed.MessageID, "Relayed message was deleted"); err != nil {
+			logger.Error("Failed to delete relayed copy of message %s: %v", sourceID, err)
+		}
+	}
+
+	return store.RelayedMessages().DeleteBySource(sourceID)
+}
+
+// markFooter adds a label to the footer of an embed, unless it already has it
+func markFooter(embed *discord.Embed, label string) {
+	if embed.Footer == nil {
+		embed.Footer = &discord.EmbedFooter{Text: "ModMail"}
+	}
+
+	if !strings.Contains(embed.Footer.Text, label) {
+		embed.Footer.Text += " • " + label
+	}
+}
+
+// hasField checks if an embed has a field with the given name
+func hasField(embed discord.Embed, name string) bool {
+	for _, field := range embed.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// truncate shortens text to at most limit characters, marking the cut with an ellipsis
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+
+	return string(runes[:limit-1]) + "…"
+}
@@ -2,8 +2,12 @@ package tickets
 
 import (
 	"discord-bot-tickets/bot/commands/helpers/colors"
+	"discord-bot-tickets/bot/commands/helpers/language"
 	"discord-bot-tickets/config"
-	"strings"
+	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
+	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/diamondburned/arikawa/v3/api"
@@ -11,10 +15,11 @@ import (
 	"github.com/diamondburned/arikawa/v3/state"
 )
 
-// Ticket represents a ModMail ticket with its channel and owner
+// Ticket represents a ModMail ticket with its channel, owner and persisted record
 type Ticket struct {
 	Channel *discord.Channel
 	Author  *discord.User
+	Record  *database.Ticket
 }
 
 // TicketCache stores active tickets in memory
@@ -53,10 +58,18 @@ func RemoveTicketFromCache(userID discord.UserID) {
 	ticketCache.RemoveTicket(userID)
 }
 
+// ErrNotDelivered is returned when a staff reply reached the ticket channel but could not be sent to the user
+var ErrNotDelivered = errors.New("message could not be delivered to the user")
+
 // MessageContent represents a message that can be either a regular message or a slash command message
 type MessageContent interface {
+	GetMessageID() discord.MessageID
 	GetContent() string
+	GetAttachments() []discord.Attachment
+	GetStickers() []discord.StickerItem
+	GetEmbeds() []discord.Embed
 	IsPrivateChat() bool
+	IsAnonymous() bool
 	GetAuthor() discord.User
 }
 
@@ -65,88 +78,299 @@ type RegularMessage struct {
 	Message discord.Message
 }
 
+func (m RegularMessage) GetMessageID() discord.MessageID {
+	return m.Message.ID
+}
+
 func (m RegularMessage) GetContent() string {
 	return m.Message.Content
 }
 
+func (m RegularMessage) GetAttachments() []discord.Attachment {
+	return m.Message.Attachments
+}
+
+func (m RegularMessage) GetStickers() []discord.StickerItem {
+	return m.Message.Stickers
+}
+
+func (m RegularMessage) GetEmbeds() []discord.Embed {
+	return m.Message.Embeds
+}
+
 func (m RegularMessage) IsPrivateChat() bool {
 	return !m.Message.GuildID.IsValid()
 }
 
+func (m RegularMessage) IsAnonymous() bool {
+	return false
+}
+
 func (m RegularMessage) GetAuthor() discord.User {
 	return m.Message.Author
 }
 
 // SlashCommandMessage implements MessageContent for slash command messages
 type SlashCommandMessage struct {
-	Message string
-	Author  discord.User
+	Message     string
+	Author      discord.User
+	Attachments []discord.Attachment
+	Anonymous   bool
+}
+
+func (m SlashCommandMessage) GetMessageID() discord.MessageID {
+	return discord.NullMessageID // Slash commands have no source message
 }
 
 func (m SlashCommandMessage) GetContent() string {
 	return m.Message
 }
 
+func (m SlashCommandMessage) GetAttachments() []discord.Attachment {
+	return m.Attachments
+}
+
+func (m SlashCommandMessage) GetStickers() []discord.StickerItem {
+	return nil
+}
+
+func (m SlashCommandMessage) GetEmbeds() []discord.Embed {
+	return nil
+}
+
 func (m SlashCommandMessage) IsPrivateChat() bool {
 	return false // Slash commands are always from guild channels
 }
 
+func (m SlashCommandMessage) IsAnonymous() bool {
+	return m.Anonymous
+}
+
 func (m SlashCommandMessage) GetAuthor() discord.User {
 	return m.Author
 }
 
-// CreateTicket creates a ticket for a user
+// CreateTicket creates a ticket for a user, starting with the message they sent.
+// The ticket type is the one the user chose, or nil if there are no ticket types.
 //
 // Returns: a pointer to a Ticket and an error if any
-func CreateTicket(config *config.Config, state *state.State, author discord.User, message discord.Message) (*Ticket, error) {
-	data := api.CreateChannelData{
-		Name:       author.Username,
-		Type:       discord.GuildText,
-		Topic:      "User: " + author.ID.String(),
-		CategoryID: config.Discord.CategoryID,
+func CreateTicket(config *config.Config, state *state.State, store database.Store, author discord.User, message discord.Message, ticketType *config.TicketType) (*Ticket, error) {
+	ticket, err := openAssignedTicket(config, state, store, author, ticketType)
+	if err != nil {
+		return nil, err
 	}
+	assignee := ticket.Record.ClaimedBy
 
-	channel, err := state.CreateChannel(config.Discord.GuildID, data)
-	if err != nil {
+	if err := relayFirstMessage(state, store, ticket, message, ticketType); err != nil {
 		return nil, err
 	}
 
+	var roleID discord.RoleID
+	if ticketType != nil {
+		roleID = ticketType.RoleID
+
+		if ticketType.Greeting != "" {
+			if _, err := state.SendMessage(message.ChannelID, ticketType.Greeting); err != nil {
+				logger.Error("Failed to greet the owner of ticket %d: %v", ticket.Record.ID, err)
+			}
+		}
+	}
+
+	if roleID.IsValid() || assignee.IsValid() {
+		pingStaff(state, ticket, roleID, assignee)
+	}
+
+	return ticket, nil
+}
+
+// relayFirstMessage posts the message a ticket was opened with in the ticket channel and logs it
+//
+// Returns: an error if any
+func relayFirstMessage(state *state.State, store database.Store, ticket *Ticket, message discord.Message, ticketType *config.TicketType) error {
 	embed := discord.Embed{
+		Color: PriorityColor(ticket.Record.Priority),
 		Author: &discord.EmbedAuthor{
-			Name: author.Username,
-			Icon: author.AvatarURL(),
+			Name: message.Author.Username,
+			Icon: message.Author.AvatarURL(),
 		},
-		Fields: []discord.EmbedField{
-			{
-				Value: message.Content,
-			},
+		Footer: &discord.EmbedFooter{
+			Text: "ModMail",
 		},
+	}
+
+	if ticketType != nil {
+		markFooter(&embed, ticketType.Name)
+	}
+
+	sent, err := newRelay(RegularMessage{Message: message}).send(state, ticket.Channel.ID, embed, message.Content)
+	if err != nil {
+		return err
+	}
+
+	logMessage(store, ticket, message.ID, RegularMessage{Message: message}, database.MessageInbound)
+	recordCopies(store, ticket, message.ID, sent)
+
+	return nil
+}
+
+// ContactUser opens a ticket on behalf of a staff member and sends the user the opening message
+//
+// Returns: a pointer to a Ticket and an error if any
+func ContactUser(config *config.Config, state *state.State, store database.Store, user discord.User, staff discord.User, message string) (*Ticket, error) {
+	ticket, err := openTicket(config, state, store, user, discord.NullUserID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	embed := discord.Embed{
+		Description: fmt.Sprintf(language.GetTranslation("embeds.contact.description"), staff.Mention(), user.Mention()),
+		Color:       colors.GetColor(colors.Blue),
+		Timestamp:   discord.NowTimestamp(),
 		Footer: &discord.EmbedFooter{
 			Text: "ModMail",
 		},
 	}
 
-	_, err = state.SendEmbeds(channel.ID, embed)
+	if _, err := state.SendEmbeds(ticket.Channel.ID, embed); err != nil {
+		logger.Error("Failed to post contact notice in ticket channel: " + err.Error())
+	}
+
+	err = UpdateTicket(config, state, store, user, SlashCommandMessage{
+		Message: message,
+		Author:  staff,
+	})
+	if err != nil && !errors.Is(err, ErrNotDelivered) {
+		discardTicket(state, store, ticket)
+		return nil, err
+	}
+
+	return ticket, err
+}
+
+// OpenTicketFromMessage opens a ticket on behalf of a staff member with the author of a message
+// sent in a server channel, starting with that message, and lets the author know about it.
+// The ticket is assigned to the staff member instead of the next one in the rotation.
+//
+// Returns: a pointer to a Ticket and an error if any
+func OpenTicketFromMessage(config *config.Config, state *state.State, store database.Store, message discord.Message, staff discord.User) (*Ticket, error) {
+	ticket, err := openTicket(config, state, store, message.Author, staff.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := relayFirstMessage(state, store, ticket, message, nil); err != nil {
+		discardTicket(state, store, ticket)
+		return nil, err
+	}
+
+	channelMention := message.ChannelID.Mention()
+
+	embed := discord.Embed{
+		Description: fmt.Sprintf(language.GetTranslation("embeds.contact.from_message"), staff.Mention(), message.URL(), message.Author.Mention(), channelMention),
+		Color:       colors.GetColor(colors.Blue),
+		Timestamp:   discord.NowTimestamp(),
+		Footer: &discord.EmbedFooter{
+			Text: "ModMail",
+		},
+	}
+
+	if _, err := state.SendEmbeds(ticket.Channel.ID, embed); err != nil {
+		logger.Error("Failed to post contact notice in ticket channel: " + err.Error())
+	}
+
+	privateChannel, err := state.CreatePrivateChannel(message.Author.ID)
+	if err != nil {
+		return ticket, fmt.Errorf("%w: %v", ErrNotDelivered, err)
+	}
+
+	if _, err := state.SendMessage(privateChannel.ID, fmt.Sprintf(language.GetTranslation("tickets.contact.from_message"), channelMention)); err != nil {
+		return ticket, fmt.Errorf("%w: %v", ErrNotDelivered, err)
+	}
+
+	return ticket, nil
+}
+
+// openTicket creates the channel and the stored record of a new ticket and caches it.
+// The ticket is assigned to the given staff member unless the ID is zero, which moves
+// them back in the rotation, and goes into the category of its ticket type if it has one.
+//
+// Returns: a pointer to a Ticket and an error if any
+func openTicket(config *config.Config, state *state.State, store database.Store, author discord.User, assignee discord.UserID, ticketType *config.TicketType) (*Ticket, error) {
+	data := api.CreateChannelData{
+		Name:       author.Username,
+		Type:       discord.GuildText,
+		Topic:      ticketTopic(author.ID, assignee),
+		CategoryID: config.Discord.CategoryID,
+	}
+
+	if ticketType != nil {
+		data.CategoryID = ticketType.CategoryID
+	}
+
+	channel, err := state.CreateChannel(config.Discord.GuildID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := store.Tickets().Create(author.ID, channel.ID)
 	if err != nil {
+		// Without a record the channel would later be adopted as the ticket of the user
+		if deleteErr := state.DeleteChannel(channel.ID, ""); deleteErr != nil {
+			logger.Error("Failed to delete the channel of unsaved ticket %s: %v", channel.ID, deleteErr)
+		}
 		return nil, err
 	}
 
+	if assignee.IsValid() {
+		if err := store.Tickets().Assign(record.ID, assignee); err != nil {
+			logger.Error("Failed to assign ticket %d: %v", record.ID, err)
+		} else {
+			record.ClaimedBy = assignee
+			recordAssignment(store, assignee)
+		}
+	}
+
+	if ticketType != nil {
+		if err := store.Tickets().SetType(record.ID, ticketType.Key); err != nil {
+			logger.Error("Failed to store the type of ticket %d: %v", record.ID, err)
+		} else {
+			record.Type = ticketType.Key
+		}
+	}
+
 	ticket := &Ticket{
 		Channel: channel,
 		Author:  &author,
+		Record:  record,
 	}
 	// Add to cache
 	ticketCache.AddTicket(ticket)
+
 	return ticket, nil
 }
 
+// discardTicket undoes openTicket for a ticket that could not be started, so the user is not
+// left with an empty open ticket that keeps them from opening a new one. The record is closed
+// first, so a channel that fails to delete is not adopted again by GetActiveTicket.
+func discardTicket(state *state.State, store database.Store, ticket *Ticket) {
+	ticketCache.RemoveTicket(ticket.Author.ID)
+
+	if err := store.Tickets().Close(ticket.Record.ID, discord.NullUserID, ""); err != nil {
+		logger.Error("Failed to close discarded ticket %d: %v", ticket.Record.ID, err)
+	}
+
+	if err := state.DeleteChannel(ticket.Channel.ID, ""); err != nil {
+		logger.Error("Failed to delete the channel of discarded ticket %d: %v", ticket.Record.ID, err)
+	}
+}
+
 // UpdateTicket updates the ticket with the latest message
 //
 // Returns: an error if any
-func UpdateTicket(config *config.Config, state *state.State, user discord.User, message MessageContent) error {
+func UpdateTicket(config *config.Config, state *state.State, store database.Store, user discord.User, message MessageContent) error {
 	var embedColor discord.Color
 
-	ticket, err := GetActiveTicket(config, state, &user)
+	ticket, err := GetActiveTicket(config, state, store, &user)
 	if err != nil {
 		return err
 	}
@@ -158,38 +382,48 @@ func UpdateTicket(config *config.Config, state *state.State, user discord.User,
 	}
 
 	if message.IsPrivateChat() {
-		embedColor = colors.GetColor(colors.Yellow)
+		embedColor = PriorityColor(ticket.Record.Priority)
 	} else {
 		embedColor = colors.GetColor(colors.Green)
 	}
 
+	relayed := newRelay(message)
+
 	ticketChannelEmbed := discord.Embed{
 		Color: embedColor,
 		Author: &discord.EmbedAuthor{
 			Name: messageAuthor.Username,
 			Icon: messageAuthor.AvatarURL(),
 		},
-		Fields: []discord.EmbedField{
-			{
-				Value: message.GetContent(),
-			},
-		},
 		Timestamp: discord.NowTimestamp(),
 		Footer: &discord.EmbedFooter{
 			Text: "ModMail",
 		},
 	}
 
-	_, err = state.SendEmbeds(ticket.Channel.ID, ticketChannelEmbed)
+	// Staff still see who wrote an anonymous reply
+	if message.IsAnonymous() {
+		ticketChannelEmbed.Footer.Text = "ModMail • " + language.GetTranslation("embeds.relay.anonymous")
+	}
+
+	channelCopies, err := relayed.send(state, ticket.Channel.ID, ticketChannelEmbed, message.GetContent())
 	if err != nil {
 		return err
 	}
 
+	// Staff replies have no message of their own, so the ticket channel copy stands in for it
+	sourceID := message.GetMessageID()
+	if !sourceID.IsValid() {
+		sourceID = channelCopies[0].ID
+		channelCopies = channelCopies[1:]
+	}
+	recordCopies(store, ticket, sourceID, channelCopies)
+
 	// Only send DM if it's not a private chat reply
 	if !message.IsPrivateChat() {
 		privateChannel, err := state.CreatePrivateChannel(ticket.Author.ID)
 		if err != nil {
-			return err
+			return fmt.Errorf("%w: %v", ErrNotDelivered, err)
 		}
 
 		privateChannelEmbed := discord.Embed{
@@ -198,48 +432,133 @@ func UpdateTicket(config *config.Config, state *state.State, user discord.User,
 				Name: messageAuthor.Username,
 				Icon: messageAuthor.AvatarURL(),
 			},
-			Fields: []discord.EmbedField{
-				{
-					Value: message.GetContent(),
-				},
-			},
 			Timestamp: discord.NowTimestamp(),
 			Footer: &discord.EmbedFooter{
 				Text: "ModMail",
 			},
 		}
 
-		_, err = state.SendEmbeds(privateChannel.ID, privateChannelEmbed)
+		if message.IsAnonymous() {
+			privateChannelEmbed.Author = staffIdentity(config, state)
+		}
+
+		dmCopies, err := relayed.send(state, privateChannel.ID, privateChannelEmbed, message.GetContent())
+		recordCopies(store, ticket, sourceID, dmCopies)
 		if err != nil {
-			return err
+			return fmt.Errorf("%w: %v", ErrNotDelivered, err)
 		}
 	}
 
+	direction := database.MessageOutbound
+	if message.IsPrivateChat() {
+		direction = database.MessageInbound
+	}
+	logMessage(store, ticket, sourceID, message, direction)
+
 	return nil
 }
 
-// IsChannelTicket checks if a specific channel is a ticket
+// staffIdentity gets the author shown to users on anonymous replies. That is the
+// configured staff name and icon, or otherwise the name and icon of the server.
 //
-// Returns: a boolean and an error if any
-func IsChannelTicket(state state.State, channel *discord.Channel) (isTicket bool, err error) {
-	if !strings.Contains(channel.Topic, "User: ") {
-		return false, nil
+// Returns: a pointer to an EmbedAuthor
+func staffIdentity(config *config.Config, state *state.State) *discord.EmbedAuthor {
+	author := &discord.EmbedAuthor{
+		Name: config.Discord.StaffName,
+		Icon: config.Discord.StaffIconURL,
 	}
 
-	parts := strings.Split(channel.Topic, "User: ")
-	if len(parts) < 2 {
-		return false, nil
+	if author.Name != "" {
+		return author
 	}
 
-	stripped := parts[1]
+	guild, err := state.Guild(config.Discord.GuildID)
+	if err != nil {
+		logger.Error("Failed to get guild for anonymous reply: " + err.Error())
+		author.Name = language.GetTranslation("embeds.relay.staff_team")
+		return author
+	}
+
+	author.Name = guild.Name
+	if author.Icon == "" {
+		author.Icon = guild.IconURL()
+	}
+
+	return author
+}
+
+// recordCopies stores which messages the bot sent as copies of a source message,
+// so edits and deletions of the source can be applied to them later
+func recordCopies(store database.Store, ticket *Ticket, sourceID discord.MessageID, copies []discord.Message) {
+	for _, relayed := range copies {
+		err := store.RelayedMessages().Create(&database.RelayedMessage{
+			TicketID:        ticket.Record.ID,
+			SourceMessageID: sourceID,
+			ChannelID:       relayed.ChannelID,
+			MessageID:       relayed.ID,
+		})
+		if err != nil {
+			logger.Error("Failed to record relayed copy of message %s: %v", sourceID, err)
+		}
+	}
+}
+
+// logMessage stores a relayed message in the message log of the ticket under the given message ID.
+// The message has already been delivered, so failures are only logged.
+func logMessage(store database.Store, ticket *Ticket, messageID discord.MessageID, message MessageContent, direction database.MessageDirection) {
+	author := message.GetAuthor()
+
+	var attachments []database.MessageAttachment
+	for _, attachment := range message.GetAttachments() {
+		attachments = append(attachments, database.MessageAttachment{
+			Filename:    attachment.Filename,
+			URL:         attachment.URL,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+		})
+	}
 
-	// Make sure that the given string is a valid snowflake/user ID
-	user, err := discord.ParseSnowflake(stripped)
+	// Stickers are kept as attachments, so transcripts can still show them
+	for _, sticker := range message.GetStickers() {
+		attachments = append(attachments, database.MessageAttachment{
+			Filename: sticker.Name,
+			URL:      stickerURL(sticker),
+		})
+	}
+
+	entry := &database.TicketMessage{
+		TicketID:    ticket.Record.ID,
+		MessageID:   messageID,
+		AuthorID:    author.ID,
+		AuthorName:  author.Username,
+		Direction:   direction,
+		Content:     message.GetContent(),
+		Attachments: attachments,
+	}
+
+	if err := store.Messages().Create(entry); err != nil {
+		logger.Error("Failed to log message for ticket %d: %v", ticket.Record.ID, err)
+	}
+
+	if err := store.Tickets().Touch(ticket.Record.ID, entry.CreatedAt); err != nil {
+		logger.Error("Failed to record activity for ticket %d: %v", ticket.Record.ID, err)
+	}
+}
+
+// IsChannelTicket checks if a specific channel is a ticket
+//
+// Returns: a boolean and an error if any
+func IsChannelTicket(state state.State, channel *discord.Channel) (isTicket bool, err error) {
+	user, err := topicUserID(channel.Topic)
 	if err != nil {
 		return false, err
 	}
 
-	author, err := state.User(discord.UserID(user))
+	if !user.IsValid() {
+		return false, nil
+	}
+
+	author, err := state.User(user)
 	if err != nil {
 		return false, err
 	}
@@ -254,13 +573,39 @@ func IsChannelTicket(state state.State, channel *discord.Channel) (isTicket bool
 // GetActiveTicket gets the active ticket of a user
 //
 // Returns: a pointer to a Ticket and an error if any
-func GetActiveTicket(config *config.Config, state *state.State, Author *discord.User) (*Ticket, error) {
+func GetActiveTicket(config *config.Config, state *state.State, store database.Store, Author *discord.User) (*Ticket, error) {
 	// First check the cache
 	if ticket := ticketCache.GetTicket(Author.ID); ticket != nil {
 		return ticket, nil
 	}
 
-	// If not in cache, search through Discord channels
+	// Then check the database for an open ticket
+	record, err := store.Tickets().FindOpenByUser(Author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if record != nil {
+		channel, err := state.Channel(record.ChannelID)
+		if err == nil {
+			ticket := &Ticket{
+				Channel: channel,
+				Author:  Author,
+				Record:  record,
+			}
+			// Add to cache
+			ticketCache.AddTicket(ticket)
+			return ticket, nil
+		}
+
+		// The channel is gone, most likely deleted while the bot was offline
+		logger.Warn("Ticket %d points to a missing channel, closing it: %v", record.ID, err)
+		if err := store.Tickets().Close(record.ID, discord.NullUserID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// If not in the database, search through Discord channels for tickets created before persistence
 	channels, err := state.Channels(config.Discord.GuildID)
 	if err != nil {
 		return nil, err
@@ -268,11 +613,28 @@ func GetActiveTicket(config *config.Config, state *state.State, Author *discord.
 
 	// Check if the user has an active ticket using the topic
 	for _, channel := range channels {
-		if channel.Topic == "User: "+Author.ID.String() {
+		if user, err := topicUserID(channel.Topic); err == nil && user == Author.ID {
 			channelCopy := channel
+
+			// A channel with a stored ticket is one whose close did not get to delete it
+			previous, err := store.Tickets().FindLatestByChannel(channelCopy.ID)
+			if err != nil {
+				return nil, err
+			}
+			if previous != nil {
+				logger.Warn("Channel %s of closed ticket %d was not deleted, not adopting it", channelCopy.ID, previous.ID)
+				continue
+			}
+
+			record, err := store.Tickets().Create(Author.ID, channelCopy.ID)
+			if err != nil {
+				return nil, err
+			}
+
 			ticket := &Ticket{
 				Channel: &channelCopy,
 				Author:  Author,
+				Record:  record,
 			}
 			// Add to cache
 			ticketCache.AddTicket(ticket)
@@ -284,17 +646,19 @@ func GetActiveTicket(config *config.Config, state *state.State, Author *discord.
 }
 
 // GetAuthorFromChannel gets the author of a ticket if the channel is a ticket
+//
+// Returns: a pointer to the author, nil if the channel is not a ticket, and an error if any
 func GetAuthorFromChannel(state *state.State, channel *discord.Channel) (*discord.User, error) {
-	stripped := strings.Split(channel.Topic, "User: ")[1]
-
-	// Make sure that the given string is a valid snowflake/user ID
-	user, err := discord.ParseSnowflake(stripped)
-
+	user, err := topicUserID(channel.Topic)
 	if err != nil {
 		return &discord.User{}, err
 	}
 
-	author, err := state.User(discord.UserID(user))
+	if !user.IsValid() {
+		return nil, nil
+	}
+
+	author, err := state.User(user)
 
 	if err != nil {
 		return &discord.User{}, err
@@ -325,3 +689,28 @@ func SendDirectMessage(state *state.State, user *discord.User, message discord.M
 
 	_, err = state.SendEmbeds(channel.ID, embed)
 }
+
+// MarkTicketClosed marks the open ticket of a channel as closed and removes it from the cache
+//
+// Returns: a pointer to the closed ticket record (nil if the channel had no open ticket) and an error if any
+func MarkTicketClosed(store database.Store, channelID discord.ChannelID, closedBy discord.UserID, reason string) (*database.Ticket, error) {
+	record, err := store.Tickets().FindOpenByChannel(channelID)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	if err := store.Tickets().Close(record.ID, closedBy, reason); err != nil {
+		return nil, err
+	}
+
+	RemoveTicketFromCache(record.UserID)
+	quotes.Forget(record.UserID)
+
+	// A closed ticket can no longer be closed on schedule
+	if _, err := store.ScheduledCloses().Cancel(record.ID); err != nil {
+		logger.Error("Failed to cancel scheduled close of ticket %d: %v", record.ID, err)
+	}
+
+	// Reload the record so it carries the closing details
+	return store.Tickets().FindByID(record.ID)
+}
@@ -0,0 +1,177 @@
+package transcripts
+
+import (
+	"bytes"
+	"discord-bot-tickets/database"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+// jsonTranscript is the stable JSON representation of a transcript
+type jsonTranscript struct {
+	TicketID  int64         `json:"ticket_id"`
+	UserID    string        `json:"user_id"`
+	UserName  string        `json:"user_name"`
+	Status    string        `json:"status"`
+	Priority  string        `json:"priority"`
+	Tags      []string      `json:"tags"`
+	CreatedAt time.Time     `json:"created_at"`
+	ClosedAt  *time.Time    `json:"closed_at,omitempty"`
+	ClosedBy  string        `json:"closed_by,omitempty"`
+	Reason    string        `json:"close_reason,omitempty"`
+	Messages  []jsonMessage `json:"messages"`
+}
+
+// jsonMessage is the stable JSON representation of a ticket message
+type jsonMessage struct {
+	ID          int64                        `json:"id"`
+	AuthorID    string                       `json:"author_id"`
+	AuthorName  string                       `json:"author_name"`
+	Direction   database.MessageDirection    `json:"direction"`
+	Content     string                       `json:"content"`
+	Attachments []database.MessageAttachment `json:"attachments"`
+	CreatedAt   time.Time                    `json:"created_at"`
+	EditedAt    *time.Time                   `json:"edited_at,omitempty"`
+	DeletedAt   *time.Time                   `json:"deleted_at,omitempty"`
+}
+
+func (t *Transcript) renderJSON() ([]byte, error) {
+	out := jsonTranscript{
+		TicketID:  t.Ticket.ID,
+		UserID:    t.Ticket.UserID.String(),
+		UserName:  t.UserName,
+		Status:    string(t.Ticket.Status),
+		Priority:  string(t.Ticket.Priority),
+		Tags:      t.Tags,
+		CreatedAt: t.Ticket.CreatedAt,
+		ClosedAt:  t.Ticket.ClosedAt,
+		Reason:    t.Ticket.CloseReason,
+		Messages:  make([]jsonMessage, 0, len(t.Messages)),
+	}
+
+	if t.Ticket.ClosedBy.IsValid() {
+		out.ClosedBy = t.Ticket.ClosedBy.String()
+	}
+
+	if out.Tags == nil {
+		out.Tags = []string{}
+	}
+
+	for _, message := range t.Messages {
+		attachments := message.Attachments
+		if attachments == nil {
+			attachments = []database.MessageAttachment{}
+		}
+
+		out.Messages = append(out.Messages, jsonMessage{
+			ID:          message.ID,
+			AuthorID:    message.AuthorID.String(),
+			AuthorName:  message.AuthorName,
+			Direction:   message.Direction,
+			Content:     message.Content,
+			Attachments: attachments,
+			CreatedAt:   message.CreatedAt,
+			EditedAt:    message.EditedAt,
+			DeletedAt:   message.DeletedAt,
+		})
+	}
+
+	return json.MarshalIndent(out, "", "  ")
+}
+
+func (t *Transcript) renderMarkdown() []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Ticket #%d\n\n", t.Ticket.ID)
+	fmt.Fprintf(&b, "- **User:** %s (%s)\n", t.UserName, t.Ticket.UserID)
+	fmt.Fprintf(&b, "- **Priority:** %s\n", t.Ticket.Priority)
+	if len(t.Tags) > 0 {
+		fmt.Fprintf(&b, "- **Tags:** %s\n", strings.Join(t.Tags, ", "))
+	}
+	fmt.Fprintf(&b, "- **Opened:** %s\n", t.Ticket.CreatedAt.Format(timeFormat))
+	if t.Ticket.ClosedAt != nil {
+		fmt.Fprintf(&b, "- **Closed:** %s\n", t.Ticket.ClosedAt.Format(timeFormat))
+	}
+	if t.Ticket.ClosedBy.IsValid() {
+		fmt.Fprintf(&b, "- **Closed by:** %s\n", t.Ticket.ClosedBy)
+	}
+	if t.Ticket.CloseReason != "" {
+		fmt.Fprintf(&b, "- **Reason:** %s\n", t.Ticket.CloseReason)
+	}
+	b.WriteString("\n---\n")
+
+	for _, message := range t.Messages {
+		fmt.Fprintf(&b, "\n**%s** (%s) `%s` _%s_", message.AuthorName, message.AuthorID, message.CreatedAt.Format(timeFormat), message.Direction)
+		if message.EditedAt != nil {
+			fmt.Fprintf(&b, " _(edited %s)_", message.EditedAt.Format(timeFormat))
+		}
+		if message.DeletedAt != nil {
+			fmt.Fprintf(&b, " _(deleted %s)_", message.DeletedAt.Format(timeFormat))
+		}
+		b.WriteString("\n\n")
+
+		if message.Content != "" {
+			for _, line := range strings.Split(message.Content, "\n") {
+				fmt.Fprintf(&b, "> %s\n", line)
+			}
+		}
+
+		for _, attachment := range message.Attachments {
+			fmt.Fprintf(&b, "\n📎 [%s](%s)\n", attachment.Filename, attachment.URL)
+		}
+	}
+
+	return []byte(b.String())
+}
+
+var htmlTemplate = template.Must(template.New("transcript").Funcs(template.FuncMap{
+	"formatTime": func(t time.Time) string { return t.Format(timeFormat) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Ticket #{{.Ticket.ID}}</title>
+<style>
+body { font-family: sans-serif; background: #313338; color: #dbdee1; margin: 2rem; }
+header { border-bottom: 1px solid #4e5058; margin-bottom: 1rem; }
+.message { margin: 0.75rem 0; padding: 0.5rem 0.75rem; border-left: 4px solid #4e5058; }
+.message.inbound { border-color: #fee75c; }
+.message.outbound { border-color: #57f287; }
+.message.internal { border-color: #9b59b6; background: #2b2d31; }
+.author { font-weight: bold; }
+.meta { color: #949ba4; font-size: 0.8rem; margin-left: 0.5rem; }
+.content { white-space: pre-wrap; margin-top: 0.25rem; }
+a { color: #00a8fc; }
+</style>
+</head>
+<body>
+<header>
+<h1>Ticket #{{.Ticket.ID}}</h1>
+<p>User: {{.UserName}} ({{.Ticket.UserID}})</p>
+<p>Priority: {{.Ticket.Priority}}{{if .Tags}} &middot; Tags: {{range $i, $tag := .Tags}}{{if $i}}, {{end}}{{$tag}}{{end}}{{end}}</p>
+<p>Opened: {{formatTime .Ticket.CreatedAt}}{{if .Ticket.ClosedAt}} &middot; Closed: {{formatTime .Ticket.ClosedAt}}{{end}}{{if .Ticket.ClosedBy.IsValid}} &middot; Closed by: {{.Ticket.ClosedBy}}{{end}}</p>
+{{if .Ticket.CloseReason}}<p>Reason: {{.Ticket.CloseReason}}</p>{{end}}
+</header>
+{{range .Messages}}<div class="message {{.Direction}}">
+<span class="author">{{.AuthorName}}</span><span class="meta">{{.AuthorID}} &middot; {{formatTime .CreatedAt}} &middot; {{.Direction}}{{if .EditedAt}} &middot; edited {{formatTime .EditedAt}}{{end}}{{if .DeletedAt}} &middot; deleted {{formatTime .DeletedAt}}{{end}}</span>
+{{if .Content}}<div class="content">{{.Content}}</div>{{end}}
+{{range .Attachments}}<div class="attachment">📎 <a href="{{.URL}}">{{.Filename}}</a></div>{{end}}
+</div>
+{{end}}</body>
+</html>
+`))
+
+func (t *Transcript) renderHTML() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := htmlTemplate.Execute(&buf, t); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
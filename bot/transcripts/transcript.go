@@ -0,0 +1,105 @@
+package transcripts
+
+import (
+	"bytes"
+	"discord-bot-tickets/database"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+)
+
+// Format is an output format of a transcript
+type Format string
+
+const (
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "markdown"
+	FormatJSON     Format = "json"
+)
+
+// Formats lists every supported transcript format
+var Formats = []Format{FormatHTML, FormatMarkdown, FormatJSON}
+
+// Extension returns the file extension used for the format
+func (f Format) Extension() string {
+	switch f {
+	case FormatMarkdown:
+		return "md"
+	case FormatJSON:
+		return "json"
+	default:
+		return "html"
+	}
+}
+
+// Transcript holds everything needed to render the conversation of a ticket
+type Transcript struct {
+	Ticket   database.Ticket
+	UserName string
+	Tags     []string
+	Messages []database.TicketMessage
+}
+
+// Build loads the message log and the tags of a ticket and creates a Transcript from them
+//
+// Returns: a pointer to the Transcript and an error if any
+func Build(store database.Store, ticket *database.Ticket, userName string) (*Transcript, error) {
+	messages, err := store.Messages().ListByTicket(ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := store.Tags().ListByTicket(ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transcript{
+		Ticket:   *ticket,
+		UserName: userName,
+		Tags:     tags,
+		Messages: messages,
+	}, nil
+}
+
+// Render renders the transcript in the given format
+//
+// Returns: the rendered transcript and an error if any
+func (t *Transcript) Render(format Format) ([]byte, error) {
+	switch format {
+	case FormatHTML:
+		return t.renderHTML()
+	case FormatMarkdown:
+		return t.renderMarkdown(), nil
+	case FormatJSON:
+		return t.renderJSON()
+	default:
+		return nil, fmt.Errorf("unknown transcript format: %s", format)
+	}
+}
+
+// Filename returns the file name of the transcript in the given format
+func (t *Transcript) Filename(format Format) string {
+	return fmt.Sprintf("ticket-%d.%s", t.Ticket.ID, format.Extension())
+}
+
+// Files renders the transcript in each of the given formats as files ready to be uploaded
+//
+// Returns: a slice of sendpart.File and an error if any
+func (t *Transcript) Files(formats ...Format) ([]sendpart.File, error) {
+	files := make([]sendpart.File, 0, len(formats))
+
+	for _, format := range formats {
+		data, err := t.Render(format)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, sendpart.File{
+			Name:   t.Filename(format),
+			Reader: bytes.NewReader(data),
+		})
+	}
+
+	return files, nil
+}
@@ -3,11 +3,17 @@ package main
 import (
 	"discord-bot-tickets/config"
 	"discord-bot-tickets/database"
+	logger "discord-bot-tickets/logging"
 	"log"
 	"os"
 )
 
 func main() {
+	// Loading the config logs about missing optional settings
+	if err := logger.Init(); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+
 	cfg, err := config.LoadConfig()
 
 	if err != nil {
@@ -6,13 +6,64 @@ import (
 	"github.com/diamondburned/arikawa/v3/discord"
 	_ "github.com/joho/godotenv/autoload"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	Discord DiscordConfig
-	DB      MySqlConfig
-	Port    string
+	Discord      DiscordConfig
+	Storage      StorageConfig
+	Inactivity   InactivityConfig
+	Assignment   AssignmentConfig
+	Confirmation ConfirmationConfig
+	Permissions  PermissionsConfig
+	TicketTypes  []TicketType
+	DB           MySqlConfig
+	Port         string
+}
+
+// TicketType is a kind of ticket users choose from before a ticket is opened,
+// e.g. appeals or reports. Without any ticket types tickets open straight away.
+type TicketType struct {
+	// Key identifies the type in the select menu and on stored tickets
+	Key         string
+	Name        string
+	Description string
+	// CategoryID is the category the ticket channels are created in
+	CategoryID discord.ChannelID
+	// RoleID is pinged when a ticket of this type is opened, if set
+	RoleID discord.RoleID
+	// Greeting is sent to the user once their ticket is opened, if set
+	Greeting string
+}
+
+// TicketType finds a ticket type by its key
+//
+// Returns: a pointer to the TicketType, nil if there is no such type
+func (c *Config) TicketType(key string) *TicketType {
+	for i := range c.TicketTypes {
+		if c.TicketTypes[i].Key == key {
+			return &c.TicketTypes[i]
+		}
+	}
+
+	return nil
+}
+
+// StorageDriver is the name of a storage backend
+type StorageDriver string
+
+const (
+	StorageMySQL  StorageDriver = "mysql"
+	StorageSQLite StorageDriver = "sqlite"
+	StorageMemory StorageDriver = "memory"
+)
+
+type StorageConfig struct {
+	Driver     StorageDriver
+	SQLitePath string
 }
 
 type MySqlConfig struct {
@@ -23,10 +74,109 @@ type MySqlConfig struct {
 	Table    string
 }
 
+// InactivityConfig controls closing tickets that nobody touches.
+// A zero Timeout disables the inactivity sweep.
+type InactivityConfig struct {
+	Timeout       time.Duration
+	WarningPeriod time.Duration
+}
+
+// AssignmentStrategy is the way new tickets are spread over the staff on duty
+type AssignmentStrategy string
+
+const (
+	// AssignRoundRobin gives each new ticket to whoever went longest without one
+	AssignRoundRobin AssignmentStrategy = "round_robin"
+	// AssignLeastLoaded gives each new ticket to whoever has the fewest open tickets
+	AssignLeastLoaded AssignmentStrategy = "least_loaded"
+)
+
+// AssignmentConfig controls the automatic assignment of new tickets to the staff on duty
+type AssignmentConfig struct {
+	Strategy AssignmentStrategy
+}
+
+// ConfirmationConfig controls asking users to confirm before their first message opens a ticket.
+// Timeout is how long the prompts shown before a ticket opens wait for an answer.
+type ConfirmationConfig struct {
+	Enabled bool
+	Timeout time.Duration
+}
+
+// PermissionLevel is what a member is trusted with, every level includes the ones below it
+type PermissionLevel int
+
+const (
+	// PermissionEveryone is any member of the guild
+	PermissionEveryone PermissionLevel = iota
+	// PermissionSupporter may handle tickets
+	PermissionSupporter
+	// PermissionModerator may also block users and manage shared resources such as snippets
+	PermissionModerator
+	// PermissionAdmin may do anything
+	PermissionAdmin
+)
+
+// String returns the name of a permission level as it is used in the configuration
+func (l PermissionLevel) String() string {
+	switch l {
+	case PermissionSupporter:
+		return "supporter"
+	case PermissionModerator:
+		return "moderator"
+	case PermissionAdmin:
+		return "admin"
+	default:
+		return "everyone"
+	}
+}
+
+// PermissionsConfig maps permission levels to the roles that grant them.
+// Without any roles configured only commands for everyone can be used.
+type PermissionsConfig struct {
+	Roles map[PermissionLevel][]discord.RoleID
+}
+
+// Enabled reports whether any role grants a permission level
+func (p PermissionsConfig) Enabled() bool {
+	for _, roles := range p.Roles {
+		if len(roles) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Level finds the highest permission level granted by any of the given roles
+func (p PermissionsConfig) Level(roleIDs []discord.RoleID) PermissionLevel {
+	level := PermissionEveryone
+
+	for granted, roles := range p.Roles {
+		if granted <= level {
+			continue
+		}
+
+		for _, role := range roles {
+			if slices.Contains(roleIDs, role) {
+				level = granted
+				break
+			}
+		}
+	}
+
+	return level
+}
+
 type DiscordConfig struct {
-	Token      string
-	GuildID    discord.GuildID
-	CategoryID discord.ChannelID
+	Token        string
+	GuildID      discord.GuildID
+	CategoryID   discord.ChannelID
+	LogChannelID discord.ChannelID
+	// StaffName and StaffIconURL are shown to users on anonymous replies,
+	// falling back to the server name and icon when empty
+	StaffName    string
+	StaffIconURL string
 }
 
 type ErrMissingEnvVar string
@@ -55,11 +205,91 @@ func LoadConfig() (*Config, error) {
 		channelID = uint64(discord.NullChannelID)
 	}
 
+	logChannelID, err := strconv.ParseUint(os.Getenv("DISCORD_LOG_CHANNEL_ID"), 10, 64)
+	if err != nil {
+		logger.Info("No/Invalid log channel ID provided, transcripts will not be posted")
+		logChannelID = uint64(discord.NullChannelID)
+	}
+
+	inactivity := InactivityConfig{WarningPeriod: 24 * time.Hour}
+
+	if value := os.Getenv("INACTIVITY_TIMEOUT"); value != "" {
+		inactivity.Timeout, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid INACTIVITY_TIMEOUT: %v", err)
+		}
+	}
+
+	if value := os.Getenv("INACTIVITY_WARNING_PERIOD"); value != "" {
+		inactivity.WarningPeriod, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid INACTIVITY_WARNING_PERIOD: %v", err)
+		}
+	}
+
+	assignment := AssignmentConfig{Strategy: AssignmentStrategy(os.Getenv("ASSIGNMENT_STRATEGY"))}
+
+	switch assignment.Strategy {
+	case "":
+		assignment.Strategy = AssignRoundRobin
+	case AssignRoundRobin, AssignLeastLoaded:
+	default:
+		return nil, fmt.Errorf("invalid ASSIGNMENT_STRATEGY: %s", assignment.Strategy)
+	}
+
+	confirmation := ConfirmationConfig{Timeout: 10 * time.Minute}
+
+	if value := os.Getenv("CONFIRM_TICKETS"); value != "" {
+		confirmation.Enabled, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CONFIRM_TICKETS: %v", err)
+		}
+	}
+
+	if value := os.Getenv("CONFIRM_TIMEOUT"); value != "" {
+		confirmation.Timeout, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CONFIRM_TIMEOUT: %v", err)
+		}
+	}
+
+	permissions, err := loadPermissions()
+	if err != nil {
+		return nil, err
+	}
+
+	ticketTypes, err := loadTicketTypes(discord.ChannelID(channelID))
+	if err != nil {
+		return nil, err
+	}
+
+	driver := StorageDriver(os.Getenv("DB_DRIVER"))
+	if driver == "" {
+		driver = StorageMySQL
+	}
+
+	sqlitePath := os.Getenv("SQLITE_PATH")
+	if sqlitePath == "" {
+		sqlitePath = "modmail.db"
+	}
+
 	cfg := &Config{
 		Discord: DiscordConfig{
-			Token:      os.Getenv("DISCORD_TOKEN"),
-			GuildID:    discord.GuildID(guildID),
-			CategoryID: discord.ChannelID(channelID),
+			Token:        os.Getenv("DISCORD_TOKEN"),
+			GuildID:      discord.GuildID(guildID),
+			CategoryID:   discord.ChannelID(channelID),
+			LogChannelID: discord.ChannelID(logChannelID),
+			StaffName:    os.Getenv("STAFF_NAME"),
+			StaffIconURL: os.Getenv("STAFF_ICON_URL"),
+		},
+		Inactivity:   inactivity,
+		Assignment:   assignment,
+		Confirmation: confirmation,
+		Permissions:  permissions,
+		TicketTypes:  ticketTypes,
+		Storage: StorageConfig{
+			Driver:     driver,
+			SQLitePath: sqlitePath,
 		},
 		DB: MySqlConfig{
 			Username: os.Getenv("MYSQL_USER"),
@@ -70,21 +300,28 @@ func LoadConfig() (*Config, error) {
 		},
 	}
 
-	//make sure all values are set
-	if cfg.DB.Username == "" {
-		return nil, ErrMissingEnvVar("MYSQL_USER")
-	}
+	switch cfg.Storage.Driver {
+	case StorageMySQL:
+		//make sure all values are set
+		if cfg.DB.Username == "" {
+			return nil, ErrMissingEnvVar("MYSQL_USER")
+		}
 
-	if cfg.DB.Host == "" {
-		return nil, ErrMissingEnvVar("MYSQL_HOST")
-	}
+		if cfg.DB.Host == "" {
+			return nil, ErrMissingEnvVar("MYSQL_HOST")
+		}
 
-	if cfg.DB.Port == "" {
-		return nil, ErrMissingEnvVar("MYSQL_PORT")
-	}
+		if cfg.DB.Port == "" {
+			return nil, ErrMissingEnvVar("MYSQL_PORT")
+		}
 
-	if cfg.DB.Table == "" {
-		return nil, ErrMissingEnvVar("MYSQL_TABLE")
+		if cfg.DB.Table == "" {
+			return nil, ErrMissingEnvVar("MYSQL_TABLE")
+		}
+	case StorageSQLite, StorageMemory:
+		// No external service required
+	default:
+		return nil, fmt.Errorf("invalid DB_DRIVER: %s", cfg.Storage.Driver)
 	}
 
 	if cfg.Discord.Token == "" {
@@ -93,3 +330,88 @@ func LoadConfig() (*Config, error) {
 
 	return cfg, nil
 }
+
+// loadPermissions loads the roles of each permission level from PERMISSION_SUPPORTER_ROLES,
+// PERMISSION_MODERATOR_ROLES and PERMISSION_ADMIN_ROLES, each a comma separated list of role IDs
+//
+// Returns: a PermissionsConfig and an error if any
+func loadPermissions() (PermissionsConfig, error) {
+	permissions := PermissionsConfig{Roles: make(map[PermissionLevel][]discord.RoleID)}
+
+	for _, level := range []PermissionLevel{PermissionSupporter, PermissionModerator, PermissionAdmin} {
+		name := "PERMISSION_" + strings.ToUpper(level.String()) + "_ROLES"
+
+		for _, value := range strings.Split(os.Getenv(name), ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+
+			roleID, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return PermissionsConfig{}, fmt.Errorf("invalid %s: %v", name, err)
+			}
+
+			permissions.Roles[level] = append(permissions.Roles[level], discord.RoleID(roleID))
+		}
+	}
+
+	return permissions, nil
+}
+
+// maxTicketTypes is the number of options Discord allows in a select menu
+const maxTicketTypes = 25
+
+// loadTicketTypes loads the ticket types listed in TICKET_TYPES, e.g. "appeals,reports,general".
+// Each type is configured with TICKET_TYPE_<KEY>_NAME, _DESCRIPTION, _CATEGORY_ID, _ROLE_ID
+// and _GREETING, where only the name is required and the category defaults to DISCORD_CATEGORY_ID.
+//
+// Returns: a slice of TicketType and an error if any
+func loadTicketTypes(defaultCategory discord.ChannelID) ([]TicketType, error) {
+	var types []TicketType
+
+	for _, key := range strings.Split(os.Getenv("TICKET_TYPES"), ",") {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+
+		prefix := "TICKET_TYPE_" + strings.ToUpper(key) + "_"
+
+		ticketType := TicketType{
+			Key:         key,
+			Name:        os.Getenv(prefix + "NAME"),
+			Description: os.Getenv(prefix + "DESCRIPTION"),
+			CategoryID:  defaultCategory,
+			Greeting:    os.Getenv(prefix + "GREETING"),
+		}
+
+		if ticketType.Name == "" {
+			return nil, ErrMissingEnvVar(prefix + "NAME")
+		}
+
+		if value := os.Getenv(prefix + "CATEGORY_ID"); value != "" {
+			categoryID, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %sCATEGORY_ID: %v", prefix, err)
+			}
+			ticketType.CategoryID = discord.ChannelID(categoryID)
+		}
+
+		if value := os.Getenv(prefix + "ROLE_ID"); value != "" {
+			roleID, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %sROLE_ID: %v", prefix, err)
+			}
+			ticketType.RoleID = discord.RoleID(roleID)
+		}
+
+		types = append(types, ticketType)
+	}
+
+	if len(types) > maxTicketTypes {
+		return nil, fmt.Errorf("TICKET_TYPES lists %d types, at most %d are supported", len(types), maxTicketTypes)
+	}
+
+	return types, nil
+}
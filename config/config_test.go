@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestPermissionsLevel(t *testing.T) {
+	const (
+		supporterRole discord.RoleID = 1
+		moderatorRole discord.RoleID = 2
+		adminRole     discord.RoleID = 3
+		otherRole     discord.RoleID = 4
+	)
+
+	configured := PermissionsConfig{Roles: map[PermissionLevel][]discord.RoleID{
+		PermissionSupporter: {supporterRole},
+		PermissionModerator: {moderatorRole},
+		PermissionAdmin:     {adminRole},
+	}}
+
+	// Nobody is a moderator, supporters and admins are still resolved
+	withoutModerators := PermissionsConfig{Roles: map[PermissionLevel][]discord.RoleID{
+		PermissionSupporter: {supporterRole},
+		PermissionAdmin:     {adminRole},
+	}}
+
+	tests := []struct {
+		name        string
+		permissions PermissionsConfig
+		roles       []discord.RoleID
+		want        PermissionLevel
+		wantEnabled bool
+	}{
+		{name: "no roles configured", permissions: PermissionsConfig{}, roles: []discord.RoleID{adminRole}, want: PermissionEveryone},
+		{name: "only empty levels configured", permissions: PermissionsConfig{Roles: map[PermissionLevel][]discord.RoleID{PermissionAdmin: {}}}, roles: []discord.RoleID{adminRole}, want: PermissionEveryone},
+		{name: "member without roles", permissions: configured, want: PermissionEveryone, wantEnabled: true},
+		{name: "member with an unrelated role", permissions: configured, roles: []discord.RoleID{otherRole}, want: PermissionEveryone, wantEnabled: true},
+		{name: "staff role", permissions: configured, roles: []discord.RoleID{otherRole, supporterRole}, want: PermissionSupporter, wantEnabled: true},
+		{name: "moderator role", permissions: configured, roles: []discord.RoleID{moderatorRole}, want: PermissionModerator, wantEnabled: true},
+		{name: "admin role", permissions: configured, roles: []discord.RoleID{adminRole}, want: PermissionAdmin, wantEnabled: true},
+		{name: "highest of several roles", permissions: configured, roles: []discord.RoleID{supporterRole, adminRole, moderatorRole}, want: PermissionAdmin, wantEnabled: true},
+		{name: "role of an unconfigured level", permissions: withoutModerators, roles: []discord.RoleID{moderatorRole}, want: PermissionEveryone, wantEnabled: true},
+		{name: "staff role beside an unconfigured level", permissions: withoutModerators, roles: []discord.RoleID{moderatorRole, supporterRole}, want: PermissionSupporter, wantEnabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.permissions.Level(tt.roles); got != tt.want {
+				t.Errorf("Level(%v) = %s, want %s", tt.roles, got, tt.want)
+			}
+			if got := tt.permissions.Enabled(); got != tt.wantEnabled {
+				t.Errorf("Enabled() = %v, want %v", got, tt.wantEnabled)
+			}
+		})
+	}
+}
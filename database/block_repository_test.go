@@ -0,0 +1,82 @@
+package database
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestBlockRepository(t *testing.T) {
+	now := time.Now()
+	expired := now.Add(-time.Hour)
+	temporary := now.Add(time.Hour)
+
+	forEachStore(t, func(t *testing.T, store Store) {
+		blocks := []Block{
+			{UserID: 1, BlockedBy: 50, Reason: "spam", CreatedAt: now.Add(-3 * time.Hour)},
+			{UserID: 2, BlockedBy: 50, ExpiresAt: &temporary, CreatedAt: now.Add(-2 * time.Hour)},
+			{UserID: 3, BlockedBy: 50, ExpiresAt: &expired, CreatedAt: now.Add(-4 * time.Hour)},
+		}
+		for i := range blocks {
+			if err := store.Blocks().Block(&blocks[i]); err != nil {
+				t.Fatalf("Block: %v", err)
+			}
+		}
+
+		tests := []struct {
+			name    string
+			user    discord.UserID
+			at      time.Time
+			blocked bool
+		}{
+			{"permanent block", 1, now, true},
+			{"temporary block", 2, now, true},
+			{"temporary block after it expired", 2, temporary.Add(time.Minute), false},
+			{"expired block", 3, now, false},
+			{"user who is not blocked", 4, now, false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				block, err := store.Blocks().FindActive(tt.user, tt.at)
+				if err != nil {
+					t.Fatalf("FindActive: %v", err)
+				}
+				if (block != nil) != tt.blocked {
+					t.Errorf("FindActive = %v, want blocked %v", block, tt.blocked)
+				}
+			})
+		}
+
+		active, err := store.Blocks().ListActive(now)
+		if err != nil {
+			t.Fatalf("ListActive: %v", err)
+		}
+		var users []discord.UserID
+		for _, block := range active {
+			users = append(users, block.UserID)
+		}
+		if want := []discord.UserID{1, 2}; !slices.Equal(users, want) {
+			t.Errorf("active blocks = %v, want %v", users, want)
+		}
+		if active[0].Reason != "spam" || active[0].BlockedBy != 50 || active[0].ExpiresAt != nil {
+			t.Errorf("permanent block = %+v, want the stored details", active[0])
+		}
+
+		removed, err := store.Blocks().DeleteExpired(now)
+		if err != nil || removed != 1 {
+			t.Errorf("DeleteExpired = %d, %v, want 1", removed, err)
+		}
+
+		unblocked, err := store.Blocks().Unblock(1)
+		if err != nil || !unblocked {
+			t.Errorf("Unblock of a blocked user = %v, %v, want true", unblocked, err)
+		}
+		unblocked, err = store.Blocks().Unblock(3)
+		if err != nil || unblocked {
+			t.Errorf("Unblock of a removed block = %v, %v, want false", unblocked, err)
+		}
+	})
+}
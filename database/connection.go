@@ -1,7 +1,8 @@
 // Package database handles database migrations and connections.
 //
-// This package includes functions for connecting to MySQL, running migrations,
-// and handling database errors.
+// This package includes functions for connecting to the configured storage
+// backend (MySQL, SQLite or in-memory), running migrations, and handling
+// database errors.
 package database
 
 import (
@@ -12,15 +13,24 @@ import (
 	"github.com/pterm/pterm"
 	"log"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/mysql"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "modernc.org/sqlite"
 )
 
+// migrationsDir is the folder holding a folder of migrations for each SQL driver
+var migrationsDir = "./database/migrations"
+
+// migrationDrivers are the drivers with migrations, each needs the same versions
+var migrationDrivers = []config.StorageDriver{config.StorageMySQL, config.StorageSQLite}
+
 // getDSN returns the Data Source Name (DSN) for the MySQL connection.
 //
 // It takes 2 arguments:
@@ -45,15 +55,63 @@ func getDSN(cfg *config.Config, withDB bool) string {
 	return dsn
 }
 
+// openDB opens a connection pool for the configured SQL driver.
+//
+// It takes 1 argument:
+// - cfg: a pointer to the config.Config struct
+//
+// returns: a pointer to the sql.DB struct and an error if any
+func openDB(cfg *config.Config) (*sql.DB, error) {
+	switch cfg.Storage.Driver {
+	case config.StorageMySQL:
+		return sql.Open("mysql", getDSN(cfg, true))
+	case config.StorageSQLite:
+		// Store times in a sortable format so they can be compared in queries
+		db, err := sql.Open("sqlite", cfg.Storage.SQLitePath+"?_time_format=sqlite")
+		if err != nil {
+			return nil, err
+		}
+
+		// SQLite only allows a single writer at a time
+		db.SetMaxOpenConns(1)
+
+		return db, nil
+	default:
+		return nil, fmt.Errorf("driver %s does not use an SQL database", cfg.Storage.Driver)
+	}
+}
+
+// migrationURLs returns the migration source and database URLs for the configured driver.
+// Each driver has its own migrations folder with an equivalent schema.
+//
+// It takes 1 argument:
+// - cfg: a pointer to the config.Config struct
+//
+// returns: the source URL and the database URL
+func migrationURLs(cfg *config.Config) (string, string) {
+	source := fmt.Sprintf("file://%s/%s", migrationsDir, cfg.Storage.Driver)
+
+	if cfg.Storage.Driver == config.StorageSQLite {
+		return source, fmt.Sprintf("sqlite://%s", cfg.Storage.SQLitePath)
+	}
+
+	return source, fmt.Sprintf("mysql://%s", getDSN(cfg, true))
+}
+
 // dropAllTables Drops all tables in the database.
 //
 // It takes 2 arguments:
 // - db: a pointer to the sql.DB struct
-// - dbName: a string value representing the database name
+// - cfg: a pointer to the config.Config struct
 //
 // returns: an error if any
-func dropAllTables(db *sql.DB, dbName string) error {
-	rows, err := db.Query(fmt.Sprintf("SELECT table_name FROM information_schema.tables WHERE table_schema = '%s'", dbName))
+func dropAllTables(db *sql.DB, cfg *config.Config) error {
+	query := fmt.Sprintf("SELECT table_name FROM information_schema.tables WHERE table_schema = '%s'", cfg.DB.Table)
+	if cfg.Storage.Driver == config.StorageSQLite {
+		query = "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'"
+	}
+
+	rows, err := db.Query(query)
 	if err != nil {
 		return fmt.Errorf("failed to query tables: %v", err)
 	}
@@ -78,7 +136,7 @@ func dropAllTables(db *sql.DB, dbName string) error {
 	return nil
 }
 
-// MigrateDatabase Runs migrations on the MySQL database.
+// MigrateDatabase Runs migrations on the configured database.
 // If the --fresh flag is passed, all tables are dropped before running migrations.
 //
 // It takes 2 arguments:
@@ -87,32 +145,36 @@ func dropAllTables(db *sql.DB, dbName string) error {
 //
 // returns: none
 func MigrateDatabase(cfg *config.Config, fresh bool) {
-	dsn := getDSN(cfg, true)
+	if cfg.Storage.Driver == config.StorageMemory {
+		log.Println("The memory driver does not need migrations.")
+		return
+	}
 
-	// Open MySQL connection
-	db, err := sql.Open("mysql", dsn)
+	// Open database connection
+	db, err := openDB(cfg)
 	if err != nil {
-		log.Fatalf("Failed to connect to MySQL: %v", err)
+		log.Fatalf("Failed to connect to the database: %v", err)
 	}
 	defer func(db *sql.DB) {
 		err := db.Close()
 		if err != nil {
-			log.Fatalf("Failed to close MySQL connection: %v", err)
+			log.Fatalf("Failed to close database connection: %v", err)
 		}
 	}(db)
 
 	// if the --fresh flag is passed, drop all tables
 	if fresh {
-		err := dropAllTables(db, cfg.DB.Table)
+		err := dropAllTables(db, cfg)
 		if err != nil {
 			log.Fatalf("Failed to drop all tables: %v", err)
 		}
 	}
 
 	// Run migrations
+	sourceURL, databaseURL := migrationURLs(cfg)
 	m, err := migrate.New(
-		"file://./database/migrations", // Path to your migration files with scheme
-		fmt.Sprintf("mysql://%s", dsn), // MySQL connection string
+		sourceURL,   // Path to the driver's migration files with scheme
+		databaseURL, // Database connection string
 	)
 
 	if err != nil {
@@ -147,39 +209,40 @@ func MigrateDatabase(cfg *config.Config, fresh bool) {
 	}
 }
 
-// Connect connects to the MySQL database using the provided configuration.
+// Connect connects to the configured storage backend.
 //
 // It takes 1 argument:
 // - cfg: a pointer to the config.Config struct
 //
-// returns: a pointer to the sql.DB struct and an error if any
-func Connect(cfg *config.Config) (*sql.DB, error) {
-	dsn := getDSN(cfg, true)
+// returns: the Store for the configured driver and an error if any
+func Connect(cfg *config.Config) (Store, error) {
+	if cfg.Storage.Driver == config.StorageMemory {
+		return NewMemoryStore(), nil
+	}
 
-	db, err := sql.Open("mysql", dsn)
+	db, err := openDB(cfg)
 
 	if err != nil {
-		log.Fatalf("Failed to connect to MySQL: %v", err)
+		log.Fatalf("Failed to connect to the database: %v", err)
 		return nil, err
 	}
 
 	// Check if the connection is alive
 	if err := db.Ping(); err != nil {
-		log.Fatalf("Failed to ping MySQL: %v", err)
+		log.Fatalf("Failed to ping the database: %v", err)
 		return nil, err
 	}
 
-	return db, nil
+	return NewSQLStore(db), nil
 }
 
-// CreateMigration generates a new migration file with the given name.
+// CreateMigration generates a new pair of empty up and down migration files with the given
+// name for every SQL driver, so the MySQL and SQLite migrations keep the same versions.
 //
 // Parameters:
 // - name: the name of the migration (e.g., "create_users_table").
-// - dir: the path to the migrations folder (e.g., "db/migrations").
-// - ext: the file extension (default is "sql").
 //
-// It automatically generates a sequential filename and creates the migration.
+// It automatically generates the next sequential version and creates the migration files.
 func CreateMigration(name string) {
 	if name == "" {
 		log.Fatalf("Migration name cannot be empty.")
@@ -188,22 +251,57 @@ func CreateMigration(name string) {
 	// Sanitize the name (replace spaces with underscores)
 	migrationName := strings.ReplaceAll(name, " ", "_")
 
-	// Build the command
-	cmd := exec.Command("migrate create -ext sql -dir database/migrations -seq ", migrationName)
+	version, err := nextMigrationVersion()
+	if err != nil {
+		log.Fatalf("Failed to create migration: %v", err)
+	}
 
-	// Set output to terminal
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	for _, driver := range migrationDrivers {
+		for _, direction := range []string{"up", "down"} {
+			path := filepath.Join(migrationsDir, string(driver), fmt.Sprintf("%06d_%s.%s.sql", version, migrationName, direction))
 
-	// Execute the command
-	log.Printf("Running: %s", strings.Join(cmd.Args, " "))
-	if err := cmd.Run(); err != nil {
-		log.Fatalf("Failed to create migration: %v", err)
+			file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+			if err != nil {
+				log.Fatalf("Failed to create migration: %v", err)
+			}
+			if err := file.Close(); err != nil {
+				log.Fatalf("Failed to create migration: %v", err)
+			}
+
+			log.Printf("Created %s", path)
+		}
 	}
 
 	log.Printf("Migration %s created successfully!", migrationName)
 }
 
+// nextMigrationVersion finds the version after the highest one of any driver's migrations
+//
+// returns: the next version and an error if any
+func nextMigrationVersion() (int, error) {
+	latest := 0
+
+	for _, driver := range migrationDrivers {
+		entries, err := os.ReadDir(filepath.Join(migrationsDir, string(driver)))
+		if err != nil {
+			return 0, err
+		}
+
+		for _, entry := range entries {
+			prefix, _, found := strings.Cut(entry.Name(), "_")
+			if !found {
+				continue
+			}
+
+			if version, err := strconv.Atoi(prefix); err == nil {
+				latest = max(latest, version)
+			}
+		}
+	}
+
+	return latest + 1, nil
+}
+
 // RollbackMigration rolls back the last migration applied to the database.
 //
 // It takes 1 argument:
@@ -211,24 +309,28 @@ func CreateMigration(name string) {
 //
 // returns: none
 func RollbackMigration(cfg *config.Config) {
-	dsn := getDSN(cfg, true)
+	if cfg.Storage.Driver == config.StorageMemory {
+		log.Println("The memory driver does not need migrations.")
+		return
+	}
 
-	// Open MySQL connection
-	db, err := sql.Open("mysql", dsn)
+	// Open database connection
+	db, err := openDB(cfg)
 	if err != nil {
-		log.Fatalf("Failed to connect to MySQL: %v", err)
+		log.Fatalf("Failed to connect to the database: %v", err)
 	}
 	defer func(db *sql.DB) {
 		err := db.Close()
 		if err != nil {
-			log.Fatalf("Failed to close MySQL connection: %v", err)
+			log.Fatalf("Failed to close database connection: %v", err)
 		}
 	}(db)
 
 	// Run migrations
+	sourceURL, databaseURL := migrationURLs(cfg)
 	m, err := migrate.New(
-		"file://./database/migrations",
-		fmt.Sprintf("mysql://%s", dsn),
+		sourceURL,
+		databaseURL,
 	)
 
 	if err != nil {
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestDutyRepository(t *testing.T) {
+	now := time.Now()
+
+	forEachStore(t, func(t *testing.T, store Store) {
+		starts := []struct {
+			user    discord.UserID
+			at      time.Time
+			started bool
+		}{
+			{50, now.Add(-time.Hour), true},
+			{51, now.Add(-2 * time.Hour), true},
+			{50, now, false},
+			{52, now.Add(-30 * time.Minute), true},
+		}
+		for _, start := range starts {
+			started, err := store.Duties().Start(start.user, start.at)
+			if err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+			if started != start.started {
+				t.Errorf("Start(%d) = %v, want %v", start.user, started, start.started)
+			}
+		}
+
+		stopped, err := store.Duties().Stop(52)
+		if err != nil || !stopped {
+			t.Errorf("Stop of a staff member on duty = %v, %v, want true", stopped, err)
+		}
+		stopped, err = store.Duties().Stop(52)
+		if err != nil || stopped {
+			t.Errorf("Stop of a staff member off duty = %v, %v, want false", stopped, err)
+		}
+
+		if err := store.Duties().MarkAssigned(50, now); err != nil {
+			t.Fatalf("MarkAssigned: %v", err)
+		}
+
+		duties, err := store.Duties().List()
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+
+		var users []discord.UserID
+		for _, duty := range duties {
+			users = append(users, duty.UserID)
+		}
+		if want := []discord.UserID{51, 50}; !slices.Equal(users, want) {
+			t.Fatalf("staff on duty = %v, want %v", users, want)
+		}
+		if duties[0].LastAssignedAt != nil || duties[1].LastAssignedAt == nil {
+			t.Errorf("last assigned at %v and %v, want only the second set", duties[0].LastAssignedAt, duties[1].LastAssignedAt)
+		}
+	})
+}
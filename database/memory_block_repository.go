@@ -0,0 +1,93 @@
+package database
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// memoryBlockRepository stores blocked users in a map keyed by user ID
+type memoryBlockRepository struct {
+	blocks map[discord.UserID]Block
+	mu     sync.RWMutex
+}
+
+// blockActive checks if a block is in effect at the given time
+func blockActive(block Block, now time.Time) bool {
+	return block.ExpiresAt == nil || block.ExpiresAt.After(now)
+}
+
+// Block blocks a user, replacing any earlier block of them
+func (r *memoryBlockRepository) Block(block *Block) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if block.CreatedAt.IsZero() {
+		block.CreatedAt = time.Now()
+	}
+
+	r.blocks[block.UserID] = *block
+
+	return nil
+}
+
+// Unblock lifts the block of a user, reporting whether the user was blocked
+func (r *memoryBlockRepository) Unblock(userID discord.UserID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.blocks[userID]
+	delete(r.blocks, userID)
+
+	return ok, nil
+}
+
+// FindActive finds the block of a user that is in effect at the given time
+func (r *memoryBlockRepository) FindActive(userID discord.UserID, now time.Time) (*Block, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	block, ok := r.blocks[userID]
+	if !ok || !blockActive(block, now) {
+		return nil, nil
+	}
+
+	return &block, nil
+}
+
+// ListActive lists all blocks in effect at the given time, oldest first
+func (r *memoryBlockRepository) ListActive(now time.Time) ([]Block, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var blocks []Block
+	for _, block := range r.blocks {
+		if blockActive(block, now) {
+			blocks = append(blocks, block)
+		}
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].CreatedAt.Before(blocks[j].CreatedAt)
+	})
+
+	return blocks, nil
+}
+
+// DeleteExpired removes all blocks that expired before the given time
+func (r *memoryBlockRepository) DeleteExpired(now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var removed int64
+	for userID, block := range r.blocks {
+		if !blockActive(block, now) {
+			delete(r.blocks, userID)
+			removed++
+		}
+	}
+
+	return removed, nil
+}
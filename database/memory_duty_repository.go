@@ -0,0 +1,70 @@
+package database
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// memoryDutyRepository stores the staff on duty in a map keyed by user ID
+type memoryDutyRepository struct {
+	duties map[discord.UserID]Duty
+	mu     sync.RWMutex
+}
+
+// Start puts a staff member on duty, reporting whether they were off duty before
+func (r *memoryDutyRepository) Start(userID discord.UserID, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.duties[userID]; ok {
+		return false, nil
+	}
+
+	r.duties[userID] = Duty{UserID: userID, StartedAt: at}
+
+	return true, nil
+}
+
+// Stop takes a staff member off duty, reporting whether they were on duty
+func (r *memoryDutyRepository) Stop(userID discord.UserID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.duties[userID]
+	delete(r.duties, userID)
+
+	return ok, nil
+}
+
+// List lists all staff members on duty, longest on duty first
+func (r *memoryDutyRepository) List() ([]Duty, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	duties := make([]Duty, 0, len(r.duties))
+	for _, duty := range r.duties {
+		duties = append(duties, duty)
+	}
+
+	sort.Slice(duties, func(i, j int) bool {
+		return duties[i].StartedAt.Before(duties[j].StartedAt)
+	})
+
+	return duties, nil
+}
+
+// MarkAssigned records that a staff member on duty was given a ticket
+func (r *memoryDutyRepository) MarkAssigned(userID discord.UserID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if duty, ok := r.duties[userID]; ok {
+		duty.LastAssignedAt = &at
+		r.duties[userID] = duty
+	}
+
+	return nil
+}
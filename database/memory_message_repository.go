@@ -0,0 +1,105 @@
+package database
+
+import (
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// memoryMessageRepository stores ticket messages in a slice in insertion order
+type memoryMessageRepository struct {
+	messages []TicketMessage
+	lastID   int64
+	mu       sync.RWMutex
+}
+
+// Create inserts a new message and sets its ID
+func (r *memoryMessageRepository) Create(message *TicketMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
+
+	r.lastID++
+	message.ID = r.lastID
+	r.messages = append(r.messages, *message)
+
+	return nil
+}
+
+// ListByTicket lists all messages of a ticket, oldest first
+func (r *memoryMessageRepository) ListByTicket(ticketID int64) ([]TicketMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var messages []TicketMessage
+	for _, message := range r.messages {
+		if message.TicketID == ticketID {
+			messages = append(messages, message)
+		}
+	}
+
+	return messages, nil
+}
+
+// FindByMessageID finds a message by its Discord message ID, returning nil if none exists
+func (r *memoryMessageRepository) FindByMessageID(messageID discord.MessageID) (*TicketMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.messages) - 1; i >= 0; i-- {
+		if r.messages[i].MessageID == messageID {
+			message := r.messages[i]
+			return &message, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindLatestReply finds the latest staff reply of an author in a ticket that was not deleted, returning nil if none exists
+func (r *memoryMessageRepository) FindLatestReply(ticketID int64, authorID discord.UserID) (*TicketMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.messages) - 1; i >= 0; i-- {
+		message := r.messages[i]
+		if message.TicketID == ticketID && message.AuthorID == authorID && message.Direction == MessageOutbound && message.DeletedAt == nil {
+			return &message, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Edit replaces the content of a message and records when it was edited
+func (r *memoryMessageRepository) Edit(id int64, content string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.messages {
+		if r.messages[i].ID == id {
+			r.messages[i].Content = content
+			r.messages[i].EditedAt = &at
+		}
+	}
+
+	return nil
+}
+
+// MarkDeleted records that a message was deleted
+func (r *memoryMessageRepository) MarkDeleted(id int64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.messages {
+		if r.messages[i].ID == id {
+			r.messages[i].DeletedAt = &at
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// memoryRelayedMessageRepository stores relayed message copies in a slice in insertion order
+type memoryRelayedMessageRepository struct {
+	copies []RelayedMessage
+	lastID int64
+	mu     sync.RWMutex
+}
+
+// Create inserts a new relayed message and sets its ID
+func (r *memoryRelayedMessageRepository) Create(relayed *RelayedMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if relayed.CreatedAt.IsZero() {
+		relayed.CreatedAt = time.Now()
+	}
+
+	r.lastID++
+	relayed.ID = r.lastID
+	r.copies = append(r.copies, *relayed)
+
+	return nil
+}
+
+// ListBySource lists all copies of a source message, oldest first
+func (r *memoryRelayedMessageRepository) ListBySource(sourceMessageID discord.MessageID) ([]RelayedMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var copies []RelayedMessage
+	for _, relayed := range r.copies {
+		if relayed.SourceMessageID == sourceMessageID {
+			copies = append(copies, relayed)
+		}
+	}
+
+	return copies, nil
+}
+
+// DeleteBySource removes all copies of a source message
+func (r *memoryRelayedMessageRepository) DeleteBySource(sourceMessageID discord.MessageID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.copies[:0]
+	for _, relayed := range r.copies {
+		if relayed.SourceMessageID != sourceMessageID {
+			kept = append(kept, relayed)
+		}
+	}
+	r.copies = kept
+
+	return nil
+}
+
+// DeleteByMessage removes a single copy by the ID of the copied message
+func (r *memoryRelayedMessageRepository) DeleteByMessage(messageID discord.MessageID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.copies[:0]
+	for _, relayed := range r.copies {
+		if relayed.MessageID != messageID {
+			kept = append(kept, relayed)
+		}
+	}
+	r.copies = kept
+
+	return nil
+}
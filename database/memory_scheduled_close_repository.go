@@ -0,0 +1,70 @@
+package database
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryScheduledCloseRepository stores scheduled closes in a map keyed by ticket ID
+type memoryScheduledCloseRepository struct {
+	closes map[int64]ScheduledClose
+	mu     sync.RWMutex
+}
+
+// Schedule schedules a ticket to be closed, replacing any earlier schedule for it
+func (r *memoryScheduledCloseRepository) Schedule(scheduled *ScheduledClose) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if scheduled.CreatedAt.IsZero() {
+		scheduled.CreatedAt = time.Now()
+	}
+
+	r.closes[scheduled.TicketID] = *scheduled
+
+	return nil
+}
+
+// Cancel cancels the scheduled close of a ticket
+func (r *memoryScheduledCloseRepository) Cancel(ticketID int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.closes[ticketID]
+	delete(r.closes, ticketID)
+
+	return ok, nil
+}
+
+// FindByTicket finds the scheduled close of a ticket
+func (r *memoryScheduledCloseRepository) FindByTicket(ticketID int64) (*ScheduledClose, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	scheduled, ok := r.closes[ticketID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &scheduled, nil
+}
+
+// ListDue lists all scheduled closes that are due at the given time
+func (r *memoryScheduledCloseRepository) ListDue(now time.Time) ([]ScheduledClose, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var closes []ScheduledClose
+	for _, scheduled := range r.closes {
+		if !scheduled.CloseAt.After(now) {
+			closes = append(closes, scheduled)
+		}
+	}
+
+	sort.Slice(closes, func(i, j int) bool {
+		return closes[i].CloseAt.Before(closes[j].CloseAt)
+	})
+
+	return closes, nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// memorySnippetRepository stores snippets in a map keyed by name
+type memorySnippetRepository struct {
+	snippets map[string]Snippet
+	lastID   int64
+	mu       sync.RWMutex
+}
+
+// Create inserts a new snippet and sets its ID
+func (r *memorySnippetRepository) Create(snippet *Snippet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	snippet.CreatedAt = now
+	snippet.UpdatedAt = now
+
+	r.lastID++
+	snippet.ID = r.lastID
+	r.snippets[snippet.Name] = *snippet
+
+	return nil
+}
+
+// Update replaces the content of a snippet, reporting whether it existed
+func (r *memorySnippetRepository) Update(name string, content string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snippet, ok := r.snippets[name]
+	if !ok {
+		return false, nil
+	}
+
+	snippet.Content = content
+	snippet.UpdatedAt = time.Now()
+	r.snippets[name] = snippet
+
+	return true, nil
+}
+
+// Delete removes a snippet, reporting whether it existed
+func (r *memorySnippetRepository) Delete(name string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.snippets[name]
+	delete(r.snippets, name)
+
+	return ok, nil
+}
+
+// FindByName finds a snippet by its name, returning nil if none exists
+func (r *memorySnippetRepository) FindByName(name string) (*Snippet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snippet, ok := r.snippets[name]
+	if !ok {
+		return nil, nil
+	}
+
+	return &snippet, nil
+}
+
+// List lists all snippets ordered by name
+func (r *memorySnippetRepository) List() ([]Snippet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snippets := make([]Snippet, 0, len(r.snippets))
+	for _, snippet := range r.snippets {
+		snippets = append(snippets, snippet)
+	}
+
+	sort.Slice(snippets, func(i, j int) bool {
+		return snippets[i].Name < snippets[j].Name
+	})
+
+	return snippets, nil
+}
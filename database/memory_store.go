@@ -0,0 +1,77 @@
+package database
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+// memoryStore is a Store that keeps everything in memory. Nothing survives a
+// restart, which makes it suitable for local development and tests only.
+type memoryStore struct {
+	tickets  *memoryTicketRepository
+	messages *memoryMessageRepository
+	closes   *memoryScheduledCloseRepository
+	relayed  *memoryRelayedMessageRepository
+	snippets *memorySnippetRepository
+	blocks   *memoryBlockRepository
+	duties   *memoryDutyRepository
+	tags     *memoryTagRepository
+}
+
+// NewMemoryStore creates a new empty in-memory Store
+func NewMemoryStore() Store {
+	tickets := &memoryTicketRepository{tickets: make(map[int64]*Ticket)}
+
+	return &memoryStore{
+		tickets:  tickets,
+		messages: &memoryMessageRepository{},
+		closes:   &memoryScheduledCloseRepository{closes: make(map[int64]ScheduledClose)},
+		relayed:  &memoryRelayedMessageRepository{},
+		snippets: &memorySnippetRepository{snippets: make(map[string]Snippet)},
+		blocks:   &memoryBlockRepository{blocks: make(map[discord.UserID]Block)},
+		duties:   &memoryDutyRepository{duties: make(map[discord.UserID]Duty)},
+		tags:     &memoryTagRepository{tickets: tickets, tags: make(map[int64]map[string]struct{})},
+	}
+}
+
+// Tickets returns the ticket repository
+func (s *memoryStore) Tickets() TicketRepository {
+	return s.tickets
+}
+
+// Messages returns the ticket message repository
+func (s *memoryStore) Messages() MessageRepository {
+	return s.messages
+}
+
+// ScheduledCloses returns the scheduled close repository
+func (s *memoryStore) ScheduledCloses() ScheduledCloseRepository {
+	return s.closes
+}
+
+// RelayedMessages returns the relayed message repository
+func (s *memoryStore) RelayedMessages() RelayedMessageRepository {
+	return s.relayed
+}
+
+// Snippets returns the snippet repository
+func (s *memoryStore) Snippets() SnippetRepository {
+	return s.snippets
+}
+
+// Blocks returns the block repository
+func (s *memoryStore) Blocks() BlockRepository {
+	return s.blocks
+}
+
+// Duties returns the duty repository
+func (s *memoryStore) Duties() DutyRepository {
+	return s.duties
+}
+
+// Tags returns the tag repository
+func (s *memoryStore) Tags() TagRepository {
+	return s.tags
+}
+
+// Close is a no-op for the in-memory store
+func (s *memoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"sort"
+	"sync"
+)
+
+// memoryTagRepository stores the tags of tickets in a set per ticket ID
+type memoryTagRepository struct {
+	tickets *memoryTicketRepository
+	tags    map[int64]map[string]struct{}
+	mu      sync.RWMutex
+}
+
+// Add tags a ticket, reporting whether the ticket didn't have the tag yet
+func (r *memoryTagRepository) Add(ticketID int64, tag string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tags[ticketID] == nil {
+		r.tags[ticketID] = make(map[string]struct{})
+	}
+
+	if _, ok := r.tags[ticketID][tag]; ok {
+		return false, nil
+	}
+
+	r.tags[ticketID][tag] = struct{}{}
+
+	return true, nil
+}
+
+// Remove removes a tag from a ticket, reporting whether the ticket had it
+func (r *memoryTagRepository) Remove(ticketID int64, tag string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.tags[ticketID][tag]
+	delete(r.tags[ticketID], tag)
+
+	return ok, nil
+}
+
+// ListByTicket lists the tags of a ticket in alphabetical order
+func (r *memoryTagRepository) ListByTicket(ticketID int64) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tags := make([]string, 0, len(r.tags[ticketID]))
+	for tag := range r.tags[ticketID] {
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	return tags, nil
+}
+
+// Count counts the tickets of every tag in use, most used first
+func (r *memoryTagRepository) Count() ([]TagCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, tags := range r.tags {
+		for tag := range tags {
+			counts[tag]++
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		result = append(result, TagCount{Tag: tag, Count: count})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Tag < result[j].Tag
+	})
+
+	return result, nil
+}
+
+// FindTickets lists the tickets with a tag, newest first and at most limit of them
+func (r *memoryTagRepository) FindTickets(tag string, limit int) ([]Ticket, error) {
+	r.mu.RLock()
+	var ids []int64
+	for ticketID, tags := range r.tags {
+		if _, ok := tags[tag]; ok {
+			ids = append(ids, ticketID)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] > ids[j]
+	})
+
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	tickets := make([]Ticket, 0, len(ids))
+	for _, id := range ids {
+		if ticket, _ := r.tickets.FindByID(id); ticket != nil {
+			tickets = append(tickets, *ticket)
+		}
+	}
+
+	return tickets, nil
+}
@@ -0,0 +1,213 @@
+package database
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// memoryTicketRepository stores tickets in a map keyed by ID
+type memoryTicketRepository struct {
+	tickets map[int64]*Ticket
+	lastID  int64
+	mu      sync.RWMutex
+}
+
+// Create inserts a new open ticket for the given user and channel
+func (r *memoryTicketRepository) Create(userID discord.UserID, channelID discord.ChannelID) (*Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.lastID++
+
+	ticket := &Ticket{
+		ID:             r.lastID,
+		UserID:         userID,
+		ChannelID:      channelID,
+		Status:         TicketStatusOpen,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		LastActivityAt: now,
+		Priority:       PriorityNormal,
+	}
+	r.tickets[ticket.ID] = ticket
+
+	copied := *ticket
+	return &copied, nil
+}
+
+// FindByID finds a ticket by its ID
+func (r *memoryTicketRepository) FindByID(id int64) (*Ticket, error) {
+	return r.findLatest(func(t *Ticket) bool {
+		return t.ID == id
+	}), nil
+}
+
+// FindLatestByUser finds the most recent ticket of a user, open or closed
+func (r *memoryTicketRepository) FindLatestByUser(userID discord.UserID) (*Ticket, error) {
+	return r.findLatest(func(t *Ticket) bool {
+		return t.UserID == userID
+	}), nil
+}
+
+// FindOpenByUser finds the open ticket of a user
+func (r *memoryTicketRepository) FindOpenByUser(userID discord.UserID) (*Ticket, error) {
+	return r.findLatest(func(t *Ticket) bool {
+		return t.UserID == userID && t.IsOpen()
+	}), nil
+}
+
+// FindOpenByChannel finds the open ticket of a channel
+func (r *memoryTicketRepository) FindOpenByChannel(channelID discord.ChannelID) (*Ticket, error) {
+	return r.findLatest(func(t *Ticket) bool {
+		return t.ChannelID == channelID && t.IsOpen()
+	}), nil
+}
+
+// FindLatestByChannel finds the most recent ticket of a channel, open or closed
+func (r *memoryTicketRepository) FindLatestByChannel(channelID discord.ChannelID) (*Ticket, error) {
+	return r.findLatest(func(t *Ticket) bool {
+		return t.ChannelID == channelID
+	}), nil
+}
+
+// Close marks a ticket as closed by the given user with an optional reason
+func (r *memoryTicketRepository) Close(id int64, closedBy discord.UserID, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticket, ok := r.tickets[id]
+	if !ok || !ticket.IsOpen() {
+		return nil
+	}
+
+	now := time.Now()
+	ticket.Status = TicketStatusClosed
+	ticket.ClosedAt = &now
+	ticket.ClosedBy = closedBy
+	ticket.CloseReason = reason
+	ticket.UpdatedAt = now
+
+	return nil
+}
+
+// Touch records activity on a ticket, which also resets any inactivity warning
+func (r *memoryTicketRepository) Touch(id int64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ticket, ok := r.tickets[id]; ok {
+		ticket.LastActivityAt = at
+		ticket.InactivityWarnedAt = nil
+	}
+
+	return nil
+}
+
+// MarkInactivityWarned records that the owner of a ticket was warned about inactivity
+func (r *memoryTicketRepository) MarkInactivityWarned(id int64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ticket, ok := r.tickets[id]; ok {
+		ticket.InactivityWarnedAt = &at
+	}
+
+	return nil
+}
+
+// ListInactiveSince lists the open tickets without any activity since the given time
+func (r *memoryTicketRepository) ListInactiveSince(since time.Time) ([]Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tickets []Ticket
+	for _, ticket := range r.tickets {
+		if ticket.IsOpen() && !ticket.LastActivityAt.After(since) {
+			tickets = append(tickets, *ticket)
+		}
+	}
+
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].LastActivityAt.Before(tickets[j].LastActivityAt)
+	})
+
+	return tickets, nil
+}
+
+// Assign assigns a ticket to a staff member, a zero staffID releases the ticket
+func (r *memoryTicketRepository) Assign(id int64, staffID discord.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ticket, ok := r.tickets[id]; ok {
+		ticket.ClaimedBy = staffID
+		ticket.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// SetPriority changes the priority of a ticket
+func (r *memoryTicketRepository) SetPriority(id int64, priority TicketPriority) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ticket, ok := r.tickets[id]; ok {
+		ticket.Priority = priority
+		ticket.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// SetType records the ticket type the user chose for a ticket
+func (r *memoryTicketRepository) SetType(id int64, ticketType string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ticket, ok := r.tickets[id]; ok {
+		ticket.Type = ticketType
+		ticket.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// CountOpenByClaimer counts the open tickets of every staff member that claimed at least one
+func (r *memoryTicketRepository) CountOpenByClaimer() (map[discord.UserID]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[discord.UserID]int)
+	for _, ticket := range r.tickets {
+		if ticket.IsOpen() && ticket.ClaimedBy.IsValid() {
+			counts[ticket.ClaimedBy]++
+		}
+	}
+
+	return counts, nil
+}
+
+// findLatest returns a copy of the ticket with the highest ID matching the predicate
+func (r *memoryTicketRepository) findLatest(match func(t *Ticket) bool) *Ticket {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *Ticket
+	for _, ticket := range r.tickets {
+		if match(ticket) && (found == nil || ticket.ID > found.ID) {
+			found = ticket
+		}
+	}
+
+	if found == nil {
+		return nil
+	}
+
+	copied := *found
+	return &copied
+}
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// mustCreateMessage logs a ticket message or fails the test
+func mustCreateMessage(t *testing.T, store Store, message TicketMessage) *TicketMessage {
+	t.Helper()
+
+	if err := store.Messages().Create(&message); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if message.ID == 0 {
+		t.Fatal("Create did not set the message ID")
+	}
+
+	return &message
+}
+
+func TestMessageRepository(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ticket := mustCreateTicket(t, store, 1, 10)
+		other := mustCreateTicket(t, store, 2, 11)
+
+		inbound := mustCreateMessage(t, store, TicketMessage{
+			TicketID:  ticket.ID,
+			MessageID: 1000,
+			AuthorID:  1,
+			Direction: MessageInbound,
+			Content:   "help",
+			Attachments: []MessageAttachment{
+				{Filename: "screenshot.png", URL: "https://cdn.example/screenshot.png", ContentType: "image/png", Size: 1024},
+			},
+		})
+		first := mustCreateMessage(t, store, TicketMessage{TicketID: ticket.ID, MessageID: 1001, AuthorID: 50, Direction: MessageOutbound, Content: "hello"})
+		second := mustCreateMessage(t, store, TicketMessage{TicketID: ticket.ID, MessageID: 1002, AuthorID: 50, Direction: MessageOutbound, Content: "on it"})
+		mustCreateMessage(t, store, TicketMessage{TicketID: ticket.ID, MessageID: 1003, AuthorID: 50, Direction: MessageInternal, Content: "note"})
+		mustCreateMessage(t, store, TicketMessage{TicketID: other.ID, MessageID: 1004, AuthorID: 50, Direction: MessageOutbound, Content: "elsewhere"})
+
+		messages, err := store.Messages().ListByTicket(ticket.ID)
+		if err != nil {
+			t.Fatalf("ListByTicket: %v", err)
+		}
+		var contents []string
+		for _, message := range messages {
+			contents = append(contents, message.Content)
+		}
+		if want := []string{"help", "hello", "on it", "note"}; !slices.Equal(contents, want) {
+			t.Errorf("messages = %v, want %v", contents, want)
+		}
+
+		found, err := store.Messages().FindByMessageID(inbound.MessageID)
+		if err != nil || found == nil {
+			t.Fatalf("FindByMessageID = %v, %v", found, err)
+		}
+		if found.ID != inbound.ID || !slices.Equal(found.Attachments, inbound.Attachments) {
+			t.Errorf("found message %d with attachments %v, want %d with %v", found.ID, found.Attachments, inbound.ID, inbound.Attachments)
+		}
+
+		missing, err := store.Messages().FindByMessageID(9999)
+		if err != nil || missing != nil {
+			t.Errorf("FindByMessageID of a missing message = %v, %v, want nil", missing, err)
+		}
+
+		tests := []struct {
+			name    string
+			delete  *TicketMessage
+			author  discord.UserID
+			want    int64
+			wantNil bool
+		}{
+			{name: "latest reply", author: 50, want: second.ID},
+			{name: "after the latest reply is deleted", delete: second, author: 50, want: first.ID},
+			{name: "after every reply is deleted", delete: first, author: 50, wantNil: true},
+			{name: "of an author without replies", author: 1, wantNil: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if tt.delete != nil {
+					if err := store.Messages().MarkDeleted(tt.delete.ID, time.Now()); err != nil {
+						t.Fatalf("MarkDeleted: %v", err)
+					}
+				}
+
+				reply, err := store.Messages().FindLatestReply(ticket.ID, tt.author)
+				if err != nil {
+					t.Fatalf("FindLatestReply: %v", err)
+				}
+
+				switch {
+				case tt.wantNil && reply != nil:
+					t.Errorf("found reply %d, want none", reply.ID)
+				case !tt.wantNil && (reply == nil || reply.ID != tt.want):
+					t.Errorf("found reply %v, want %d", reply, tt.want)
+				}
+			})
+		}
+	})
+}
+
+func TestMessageRepositoryEdit(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ticket := mustCreateTicket(t, store, 1, 10)
+		message := mustCreateMessage(t, store, TicketMessage{TicketID: ticket.ID, MessageID: 1000, AuthorID: 50, Direction: MessageOutbound, Content: "helo"})
+
+		if err := store.Messages().Edit(message.ID, "hello", time.Now()); err != nil {
+			t.Fatalf("Edit: %v", err)
+		}
+
+		edited, err := store.Messages().FindByMessageID(message.MessageID)
+		if err != nil {
+			t.Fatalf("FindByMessageID: %v", err)
+		}
+		if edited.Content != "hello" || edited.EditedAt == nil {
+			t.Errorf("content %q edited at %v, want %q with an edit time", edited.Content, edited.EditedAt, "hello")
+		}
+		if edited.DeletedAt != nil {
+			t.Errorf("edited message is deleted at %v", edited.DeletedAt)
+		}
+	})
+}
+
+func TestRelayedMessageRepository(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ticket := mustCreateTicket(t, store, 1, 10)
+
+		copies := []RelayedMessage{
+			{TicketID: ticket.ID, SourceMessageID: 1000, ChannelID: 10, MessageID: 2000},
+			{TicketID: ticket.ID, SourceMessageID: 1000, ChannelID: 20, MessageID: 2001},
+			{TicketID: ticket.ID, SourceMessageID: 1001, ChannelID: 10, MessageID: 2002},
+		}
+		for i := range copies {
+			if err := store.RelayedMessages().Create(&copies[i]); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+
+		tests := []struct {
+			name   string
+			change func() error
+			source discord.MessageID
+			want   []discord.MessageID
+		}{
+			{name: "copies of a source", source: 1000, want: []discord.MessageID{2000, 2001}},
+			{name: "copies of another source", source: 1001, want: []discord.MessageID{2002}},
+			{
+				name:   "after deleting a copy",
+				change: func() error { return store.RelayedMessages().DeleteByMessage(2000) },
+				source: 1000,
+				want:   []discord.MessageID{2001},
+			},
+			{
+				name:   "after deleting by source",
+				change: func() error { return store.RelayedMessages().DeleteBySource(1000) },
+				source: 1000,
+				want:   []discord.MessageID{},
+			},
+			{name: "other source is kept", source: 1001, want: []discord.MessageID{2002}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if tt.change != nil {
+					if err := tt.change(); err != nil {
+						t.Fatalf("change: %v", err)
+					}
+				}
+
+				relayed, err := store.RelayedMessages().ListBySource(tt.source)
+				if err != nil {
+					t.Fatalf("ListBySource: %v", err)
+				}
+
+				got := make([]discord.MessageID, 0, len(relayed))
+				for _, copied := range relayed {
+					got = append(got, copied.MessageID)
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("copies = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,8 @@
+ALTER TABLE tickets
+    DROP INDEX idx_tickets_channel,
+    DROP INDEX idx_tickets_user_status,
+    DROP COLUMN closed_by,
+    DROP COLUMN closed_at,
+    DROP COLUMN updated_at,
+    DROP COLUMN created_at,
+    DROP COLUMN status;
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE tickets
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'open',
+    ADD COLUMN created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN closed_at DATETIME NULL,
+    ADD COLUMN closed_by BIGINT NULL,
+    ADD INDEX idx_tickets_user_status (user_id, status),
+    ADD INDEX idx_tickets_channel (channel_id);
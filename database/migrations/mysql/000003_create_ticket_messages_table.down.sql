@@ -0,0 +1 @@
+DROP TABLE ticket_messages;
\ No newline at end of file
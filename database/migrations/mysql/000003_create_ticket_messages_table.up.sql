@@ -0,0 +1,13 @@
+CREATE TABLE ticket_messages (
+                         id INT AUTO_INCREMENT PRIMARY KEY,
+                         ticket_id INT NOT NULL,
+                         message_id BIGINT NULL,
+                         author_id BIGINT NOT NULL,
+                         author_name VARCHAR(255) NOT NULL,
+                         direction VARCHAR(16) NOT NULL,
+                         content TEXT NOT NULL,
+                         attachments TEXT NOT NULL,
+                         created_at DATETIME NOT NULL,
+                         INDEX idx_ticket_messages_ticket (ticket_id),
+                         FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE tickets ADD COLUMN close_reason TEXT NULL;
\ No newline at end of file
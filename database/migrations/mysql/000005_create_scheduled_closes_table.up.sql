@@ -0,0 +1,10 @@
+CREATE TABLE scheduled_closes (
+                         ticket_id INT PRIMARY KEY,
+                         close_at DATETIME NOT NULL,
+                         scheduled_by BIGINT NOT NULL,
+                         reason TEXT NULL,
+                         silent BOOLEAN NOT NULL DEFAULT 0,
+                         created_at DATETIME NOT NULL,
+                         INDEX idx_scheduled_closes_close_at (close_at),
+                         FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE
+);
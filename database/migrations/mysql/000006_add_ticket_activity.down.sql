@@ -0,0 +1,4 @@
+ALTER TABLE tickets
+    DROP INDEX idx_tickets_status_activity,
+    DROP COLUMN inactivity_warned_at,
+    DROP COLUMN last_activity_at;
\ No newline at end of file
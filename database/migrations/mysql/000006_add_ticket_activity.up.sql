@@ -0,0 +1,5 @@
+ALTER TABLE tickets
+    ADD COLUMN last_activity_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',
+    ADD COLUMN inactivity_warned_at DATETIME NULL,
+    ADD INDEX idx_tickets_status_activity (status, last_activity_at);
+UPDATE tickets SET last_activity_at = updated_at;
@@ -0,0 +1,5 @@
+ALTER TABLE ticket_messages
+    DROP INDEX idx_ticket_messages_message,
+    DROP COLUMN deleted_at,
+    DROP COLUMN edited_at;
+DROP TABLE relayed_messages;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE relayed_messages (
+                         id INT AUTO_INCREMENT PRIMARY KEY,
+                         ticket_id INT NOT NULL,
+                         source_message_id BIGINT NOT NULL,
+                         channel_id BIGINT NOT NULL,
+                         message_id BIGINT NOT NULL,
+                         created_at DATETIME NOT NULL,
+                         INDEX idx_relayed_messages_source (source_message_id),
+                         FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE
+);
+ALTER TABLE ticket_messages
+    ADD COLUMN edited_at DATETIME NULL,
+    ADD COLUMN deleted_at DATETIME NULL,
+    ADD INDEX idx_ticket_messages_message (message_id);
@@ -0,0 +1 @@
+DROP TABLE snippets;
\ No newline at end of file
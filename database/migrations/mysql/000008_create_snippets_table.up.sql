@@ -0,0 +1,9 @@
+CREATE TABLE snippets (
+                         id INT AUTO_INCREMENT PRIMARY KEY,
+                         name VARCHAR(100) NOT NULL,
+                         content TEXT NOT NULL,
+                         created_by BIGINT NOT NULL,
+                         created_at DATETIME NOT NULL,
+                         updated_at DATETIME NOT NULL,
+                         UNIQUE INDEX idx_snippets_name (name)
+);
@@ -0,0 +1 @@
+DROP TABLE blocked_users;
\ No newline at end of file
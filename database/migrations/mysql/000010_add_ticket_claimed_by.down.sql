@@ -0,0 +1 @@
+ALTER TABLE tickets DROP COLUMN claimed_by;
\ No newline at end of file
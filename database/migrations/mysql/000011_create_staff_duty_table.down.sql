@@ -0,0 +1 @@
+DROP TABLE staff_duty;
\ No newline at end of file
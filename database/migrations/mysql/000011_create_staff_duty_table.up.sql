@@ -0,0 +1,5 @@
+CREATE TABLE staff_duty (
+                         user_id BIGINT PRIMARY KEY,
+                         started_at DATETIME NOT NULL,
+                         last_assigned_at DATETIME NULL
+);
@@ -0,0 +1,2 @@
+DROP TABLE ticket_tags;
+ALTER TABLE tickets DROP COLUMN priority;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE tickets ADD COLUMN priority VARCHAR(16) NOT NULL DEFAULT 'normal';
+CREATE TABLE ticket_tags (
+                         ticket_id INT NOT NULL,
+                         tag VARCHAR(32) NOT NULL,
+                         created_at DATETIME NOT NULL,
+                         PRIMARY KEY (ticket_id, tag),
+                         INDEX idx_ticket_tags_tag (tag),
+                         FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE
+);
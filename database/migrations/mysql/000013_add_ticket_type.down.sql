@@ -0,0 +1 @@
+ALTER TABLE tickets DROP COLUMN ticket_type;
\ No newline at end of file
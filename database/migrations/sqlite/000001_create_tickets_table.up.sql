@@ -0,0 +1,5 @@
+CREATE TABLE tickets (
+                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                         user_id BIGINT NOT NULL,
+                         channel_id BIGINT NOT NULL
+);
@@ -0,0 +1,7 @@
+DROP INDEX idx_tickets_channel;
+DROP INDEX idx_tickets_user_status;
+ALTER TABLE tickets DROP COLUMN closed_by;
+ALTER TABLE tickets DROP COLUMN closed_at;
+ALTER TABLE tickets DROP COLUMN updated_at;
+ALTER TABLE tickets DROP COLUMN created_at;
+ALTER TABLE tickets DROP COLUMN status;
\ No newline at end of file
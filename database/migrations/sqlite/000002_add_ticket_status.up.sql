@@ -0,0 +1,7 @@
+ALTER TABLE tickets ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'open';
+ALTER TABLE tickets ADD COLUMN created_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00';
+ALTER TABLE tickets ADD COLUMN updated_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00';
+ALTER TABLE tickets ADD COLUMN closed_at DATETIME NULL;
+ALTER TABLE tickets ADD COLUMN closed_by BIGINT NULL;
+CREATE INDEX idx_tickets_user_status ON tickets (user_id, status);
+CREATE INDEX idx_tickets_channel ON tickets (channel_id);
@@ -0,0 +1,12 @@
+CREATE TABLE ticket_messages (
+                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                         ticket_id INTEGER NOT NULL REFERENCES tickets (id) ON DELETE CASCADE,
+                         message_id BIGINT NULL,
+                         author_id BIGINT NOT NULL,
+                         author_name VARCHAR(255) NOT NULL,
+                         direction VARCHAR(16) NOT NULL,
+                         content TEXT NOT NULL,
+                         attachments TEXT NOT NULL,
+                         created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_ticket_messages_ticket ON ticket_messages (ticket_id);
@@ -0,0 +1 @@
+ALTER TABLE tickets DROP COLUMN close_reason;
\ No newline at end of file
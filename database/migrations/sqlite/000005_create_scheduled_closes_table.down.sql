@@ -0,0 +1 @@
+DROP TABLE scheduled_closes;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE scheduled_closes (
+                         ticket_id INTEGER PRIMARY KEY REFERENCES tickets (id) ON DELETE CASCADE,
+                         close_at DATETIME NOT NULL,
+                         scheduled_by BIGINT NOT NULL,
+                         reason TEXT NULL,
+                         silent BOOLEAN NOT NULL DEFAULT 0,
+                         created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_scheduled_closes_close_at ON scheduled_closes (close_at);
@@ -0,0 +1,3 @@
+DROP INDEX idx_tickets_status_activity;
+ALTER TABLE tickets DROP COLUMN inactivity_warned_at;
+ALTER TABLE tickets DROP COLUMN last_activity_at;
\ No newline at end of file
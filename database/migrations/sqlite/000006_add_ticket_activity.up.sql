@@ -0,0 +1,4 @@
+ALTER TABLE tickets ADD COLUMN last_activity_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00';
+ALTER TABLE tickets ADD COLUMN inactivity_warned_at DATETIME NULL;
+CREATE INDEX idx_tickets_status_activity ON tickets (status, last_activity_at);
+UPDATE tickets SET last_activity_at = updated_at;
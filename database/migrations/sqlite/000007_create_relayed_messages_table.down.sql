@@ -0,0 +1,4 @@
+DROP INDEX idx_ticket_messages_message;
+ALTER TABLE ticket_messages DROP COLUMN deleted_at;
+ALTER TABLE ticket_messages DROP COLUMN edited_at;
+DROP TABLE relayed_messages;
\ No newline at end of file
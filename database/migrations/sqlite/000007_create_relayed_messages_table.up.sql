@@ -0,0 +1,12 @@
+CREATE TABLE relayed_messages (
+                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                         ticket_id INTEGER NOT NULL REFERENCES tickets (id) ON DELETE CASCADE,
+                         source_message_id BIGINT NOT NULL,
+                         channel_id BIGINT NOT NULL,
+                         message_id BIGINT NOT NULL,
+                         created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_relayed_messages_source ON relayed_messages (source_message_id);
+ALTER TABLE ticket_messages ADD COLUMN edited_at DATETIME NULL;
+ALTER TABLE ticket_messages ADD COLUMN deleted_at DATETIME NULL;
+CREATE INDEX idx_ticket_messages_message ON ticket_messages (message_id);
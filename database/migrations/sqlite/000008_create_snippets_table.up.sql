@@ -0,0 +1,9 @@
+CREATE TABLE snippets (
+                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                         name VARCHAR(100) NOT NULL,
+                         content TEXT NOT NULL,
+                         created_by BIGINT NOT NULL,
+                         created_at DATETIME NOT NULL,
+                         updated_at DATETIME NOT NULL
+);
+CREATE UNIQUE INDEX idx_snippets_name ON snippets (name);
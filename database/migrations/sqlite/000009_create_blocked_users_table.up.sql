@@ -0,0 +1,8 @@
+CREATE TABLE blocked_users (
+                         user_id BIGINT PRIMARY KEY,
+                         blocked_by BIGINT NOT NULL,
+                         reason TEXT NULL,
+                         expires_at DATETIME NULL,
+                         created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_blocked_users_expires_at ON blocked_users (expires_at);
@@ -0,0 +1 @@
+ALTER TABLE tickets ADD COLUMN claimed_by BIGINT NULL;
\ No newline at end of file
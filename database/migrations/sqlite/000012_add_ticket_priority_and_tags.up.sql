@@ -0,0 +1,8 @@
+ALTER TABLE tickets ADD COLUMN priority VARCHAR(16) NOT NULL DEFAULT 'normal';
+CREATE TABLE ticket_tags (
+                         ticket_id INTEGER NOT NULL REFERENCES tickets (id) ON DELETE CASCADE,
+                         tag VARCHAR(32) NOT NULL,
+                         created_at DATETIME NOT NULL,
+                         PRIMARY KEY (ticket_id, tag)
+);
+CREATE INDEX idx_ticket_tags_tag ON ticket_tags (tag);
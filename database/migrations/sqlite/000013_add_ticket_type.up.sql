@@ -0,0 +1 @@
+ALTER TABLE tickets ADD COLUMN ticket_type VARCHAR(32) NULL;
\ No newline at end of file
@@ -0,0 +1,144 @@
+package database
+
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// TicketStatus represents the lifecycle state of a ticket
+type TicketStatus string
+
+const (
+	TicketStatusOpen   TicketStatus = "open"
+	TicketStatusClosed TicketStatus = "closed"
+)
+
+// TicketPriority describes how urgently a ticket needs attention
+type TicketPriority string
+
+const (
+	PriorityLow    TicketPriority = "low"
+	PriorityNormal TicketPriority = "normal"
+	PriorityHigh   TicketPriority = "high"
+	PriorityUrgent TicketPriority = "urgent"
+)
+
+// Priorities lists every ticket priority from lowest to highest
+var Priorities = []TicketPriority{PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent}
+
+// Ticket represents a single row of the tickets table
+type Ticket struct {
+	ID                 int64
+	UserID             discord.UserID
+	ChannelID          discord.ChannelID
+	Status             TicketStatus
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	ClosedAt           *time.Time
+	ClosedBy           discord.UserID
+	CloseReason        string
+	LastActivityAt     time.Time
+	InactivityWarnedAt *time.Time
+	// ClaimedBy is the staff member handling the ticket, zero if nobody claimed it
+	ClaimedBy discord.UserID
+	Priority  TicketPriority
+	// Type is the key of the ticket type the user chose, empty if they didn't choose one
+	Type string
+}
+
+// IsOpen reports whether the ticket is still open
+func (t *Ticket) IsOpen() bool {
+	return t.Status == TicketStatusOpen
+}
+
+// TagCount is the number of tickets that carry a tag
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// MessageDirection describes which way a ticket message was relayed
+type MessageDirection string
+
+const (
+	// MessageInbound is a message sent by the user to the staff team
+	MessageInbound MessageDirection = "inbound"
+	// MessageOutbound is a reply sent by a staff member to the user
+	MessageOutbound MessageDirection = "outbound"
+	// MessageInternal is a note between staff members that is never sent to the user
+	MessageInternal MessageDirection = "internal"
+)
+
+// MessageAttachment describes a file that was attached to a ticket message
+type MessageAttachment struct {
+	Filename    string `json:"filename"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        uint64 `json:"size"`
+}
+
+// TicketMessage represents a single row of the ticket_messages table
+type TicketMessage struct {
+	ID          int64
+	TicketID    int64
+	MessageID   discord.MessageID
+	AuthorID    discord.UserID
+	AuthorName  string
+	Direction   MessageDirection
+	Content     string
+	Attachments []MessageAttachment
+	CreatedAt   time.Time
+	EditedAt    *time.Time
+	DeletedAt   *time.Time
+}
+
+// RelayedMessage represents a single row of the relayed_messages table. It
+// maps a message to one of the copies the bot sent of it, so edits and
+// deletions of the source can be applied to the copies.
+type RelayedMessage struct {
+	ID              int64
+	TicketID        int64
+	SourceMessageID discord.MessageID
+	ChannelID       discord.ChannelID
+	MessageID       discord.MessageID
+	CreatedAt       time.Time
+}
+
+// ScheduledClose represents a single row of the scheduled_closes table
+type ScheduledClose struct {
+	TicketID    int64
+	CloseAt     time.Time
+	ScheduledBy discord.UserID
+	Reason      string
+	Silent      bool
+	CreatedAt   time.Time
+}
+
+// Snippet represents a single row of the snippets table, a saved response staff can send to users
+type Snippet struct {
+	ID        int64
+	Name      string
+	Content   string
+	CreatedBy discord.UserID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Block represents a single row of the blocked_users table, a user who can't open or reply to tickets
+type Block struct {
+	UserID    discord.UserID
+	BlockedBy discord.UserID
+	Reason    string
+	// ExpiresAt is nil for permanent blocks
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+// Duty represents a single row of the staff_duty table, a staff member who takes new tickets
+type Duty struct {
+	UserID    discord.UserID
+	StartedAt time.Time
+	// LastAssignedAt is nil until the staff member is given a ticket
+	LastAssignedAt *time.Time
+}
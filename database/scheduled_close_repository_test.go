@@ -0,0 +1,74 @@
+package database
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestScheduledCloseRepository(t *testing.T) {
+	now := time.Now()
+
+	forEachStore(t, func(t *testing.T, store Store) {
+		soon := mustCreateTicket(t, store, 1, 10)
+		later := mustCreateTicket(t, store, 2, 11)
+		overdue := mustCreateTicket(t, store, 3, 12)
+
+		schedules := []ScheduledClose{
+			{TicketID: soon.ID, CloseAt: now.Add(time.Hour), ScheduledBy: 50, Reason: "first"},
+			{TicketID: later.ID, CloseAt: now.Add(3 * time.Hour), ScheduledBy: 50},
+			{TicketID: overdue.ID, CloseAt: now.Add(-time.Hour), ScheduledBy: 50, Silent: true},
+			// Scheduling again replaces the earlier schedule
+			{TicketID: soon.ID, CloseAt: now.Add(2 * time.Hour), ScheduledBy: 51, Reason: "second"},
+		}
+		for i := range schedules {
+			if err := store.ScheduledCloses().Schedule(&schedules[i]); err != nil {
+				t.Fatalf("Schedule: %v", err)
+			}
+		}
+
+		scheduled, err := store.ScheduledCloses().FindByTicket(soon.ID)
+		if err != nil || scheduled == nil {
+			t.Fatalf("FindByTicket = %v, %v", scheduled, err)
+		}
+		if scheduled.ScheduledBy != 51 || scheduled.Reason != "second" {
+			t.Errorf("schedule by %d for %q, want the replacement by 51 for %q", scheduled.ScheduledBy, scheduled.Reason, "second")
+		}
+
+		tests := []struct {
+			name string
+			at   time.Time
+			want []int64
+		}{
+			{"now", now, []int64{overdue.ID}},
+			{"in two hours", now.Add(2 * time.Hour), []int64{overdue.ID, soon.ID}},
+			{"in a day", now.Add(24 * time.Hour), []int64{overdue.ID, soon.ID, later.ID}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				due, err := store.ScheduledCloses().ListDue(tt.at)
+				if err != nil {
+					t.Fatalf("ListDue: %v", err)
+				}
+
+				got := make([]int64, 0, len(due))
+				for _, close := range due {
+					got = append(got, close.TicketID)
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("due closes = %v, want %v", got, tt.want)
+				}
+			})
+		}
+
+		cancelled, err := store.ScheduledCloses().Cancel(soon.ID)
+		if err != nil || !cancelled {
+			t.Errorf("Cancel of a scheduled close = %v, %v, want true", cancelled, err)
+		}
+		cancelled, err = store.ScheduledCloses().Cancel(soon.ID)
+		if err != nil || cancelled {
+			t.Errorf("Cancel of a cancelled close = %v, %v, want false", cancelled, err)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSnippetRepository(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		for _, name := range []string{"welcome", "appeal", "rules"} {
+			snippet := &Snippet{Name: name, Content: name + " text", CreatedBy: 50}
+			if err := store.Snippets().Create(snippet); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			if snippet.ID == 0 {
+				t.Fatal("Create did not set the snippet ID")
+			}
+		}
+
+		tests := []struct {
+			name    string
+			change  func() (bool, error)
+			changed bool
+		}{
+			{"update", func() (bool, error) { return store.Snippets().Update("rules", "be nice") }, true},
+			{"update of a missing snippet", func() (bool, error) { return store.Snippets().Update("faq", "") }, false},
+			{"delete", func() (bool, error) { return store.Snippets().Delete("appeal") }, true},
+			{"delete of a missing snippet", func() (bool, error) { return store.Snippets().Delete("appeal") }, false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				changed, err := tt.change()
+				if err != nil {
+					t.Fatalf("change: %v", err)
+				}
+				if changed != tt.changed {
+					t.Errorf("changed = %v, want %v", changed, tt.changed)
+				}
+			})
+		}
+
+		rules, err := store.Snippets().FindByName("rules")
+		if err != nil || rules == nil {
+			t.Fatalf("FindByName = %v, %v", rules, err)
+		}
+		if rules.Content != "be nice" || rules.CreatedBy != 50 {
+			t.Errorf("snippet has content %q by %d, want %q by 50", rules.Content, rules.CreatedBy, "be nice")
+		}
+
+		missing, err := store.Snippets().FindByName("appeal")
+		if err != nil || missing != nil {
+			t.Errorf("FindByName of a deleted snippet = %v, %v, want nil", missing, err)
+		}
+
+		snippets, err := store.Snippets().List()
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		var names []string
+		for _, snippet := range snippets {
+			names = append(names, snippet.Name)
+		}
+		if want := []string{"rules", "welcome"}; !slices.Equal(names, want) {
+			t.Errorf("snippets = %v, want %v", names, want)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// sqlBlockRepository reads and writes blocked users to the blocked_users table.
+// Times are stored in UTC so they compare correctly on every backend.
+type sqlBlockRepository struct {
+	db *sql.DB
+}
+
+const blockColumns = "user_id, blocked_by, reason, expires_at, created_at"
+
+// scanBlock scans a single blocked user row into a Block struct
+func scanBlock(row scanner) (*Block, error) {
+	var (
+		block     Block
+		userID    int64
+		blockedBy int64
+		reason    sql.NullString
+		expiresAt sql.NullTime
+	)
+
+	if err := row.Scan(&userID, &blockedBy, &reason, &expiresAt, &block.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	block.UserID = discord.UserID(userID)
+	block.BlockedBy = discord.UserID(blockedBy)
+	block.Reason = reason.String
+	if expiresAt.Valid {
+		block.ExpiresAt = &expiresAt.Time
+	}
+
+	return &block, nil
+}
+
+// Block blocks a user, replacing any earlier block of them
+//
+// Returns: an error if any
+func (r *sqlBlockRepository) Block(block *Block) error {
+	if block.CreatedAt.IsZero() {
+		block.CreatedAt = time.Now()
+	}
+
+	var expiresAt sql.NullTime
+	if block.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: block.ExpiresAt.UTC(), Valid: true}
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM blocked_users WHERE user_id = ?", int64(block.UserID)); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO blocked_users (user_id, blocked_by, reason, expires_at, created_at) VALUES (?, ?, ?, ?, ?)",
+		int64(block.UserID), int64(block.BlockedBy), sql.NullString{String: block.Reason, Valid: block.Reason != ""}, expiresAt, block.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Unblock lifts the block of a user
+//
+// Returns: whether the user was blocked and an error if any
+func (r *sqlBlockRepository) Unblock(userID discord.UserID) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM blocked_users WHERE user_id = ?", int64(userID))
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+
+	return affected > 0, err
+}
+
+// FindActive finds the block of a user that is in effect at the given time
+//
+// Returns: a pointer to the Block (nil if the user isn't blocked) and an error if any
+func (r *sqlBlockRepository) FindActive(userID discord.UserID, now time.Time) (*Block, error) {
+	row := r.db.QueryRow(
+		"SELECT "+blockColumns+" FROM blocked_users WHERE user_id = ? AND (expires_at IS NULL OR expires_at > ?)",
+		int64(userID), now.UTC(),
+	)
+
+	block, err := scanBlock(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return block, err
+}
+
+// ListActive lists all blocks in effect at the given time, oldest first
+//
+// Returns: a slice of Block and an error if any
+func (r *sqlBlockRepository) ListActive(now time.Time) ([]Block, error) {
+	rows, err := r.db.Query(
+		"SELECT "+blockColumns+" FROM blocked_users WHERE expires_at IS NULL OR expires_at > ? ORDER BY created_at",
+		now.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocks []Block
+	for rows.Next() {
+		block, err := scanBlock(rows)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, *block)
+	}
+
+	return blocks, rows.Err()
+}
+
+// DeleteExpired removes all blocks that expired before the given time
+//
+// Returns: the number of removed blocks and an error if any
+func (r *sqlBlockRepository) DeleteExpired(now time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM blocked_users WHERE expires_at IS NOT NULL AND expires_at <= ?", now.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
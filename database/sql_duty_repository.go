@@ -0,0 +1,89 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// sqlDutyRepository reads and writes the staff on duty to the staff_duty table
+type sqlDutyRepository struct {
+	db *sql.DB
+}
+
+// Start puts a staff member on duty
+//
+// Returns: whether they were off duty before and an error if any
+func (r *sqlDutyRepository) Start(userID discord.UserID, at time.Time) (bool, error) {
+	var count int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM staff_duty WHERE user_id = ?", int64(userID)).Scan(&count); err != nil {
+		return false, err
+	}
+
+	if count > 0 {
+		return false, nil
+	}
+
+	if _, err := r.db.Exec("INSERT INTO staff_duty (user_id, started_at) VALUES (?, ?)", int64(userID), at.UTC()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Stop takes a staff member off duty
+//
+// Returns: whether they were on duty and an error if any
+func (r *sqlDutyRepository) Stop(userID discord.UserID) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM staff_duty WHERE user_id = ?", int64(userID))
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+
+	return affected > 0, err
+}
+
+// List lists all staff members on duty, longest on duty first
+//
+// Returns: a slice of Duty and an error if any
+func (r *sqlDutyRepository) List() ([]Duty, error) {
+	rows, err := r.db.Query("SELECT user_id, started_at, last_assigned_at FROM staff_duty ORDER BY started_at")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var duties []Duty
+	for rows.Next() {
+		var (
+			duty           Duty
+			userID         int64
+			lastAssignedAt sql.NullTime
+		)
+
+		if err := rows.Scan(&userID, &duty.StartedAt, &lastAssignedAt); err != nil {
+			return nil, err
+		}
+
+		duty.UserID = discord.UserID(userID)
+		if lastAssignedAt.Valid {
+			duty.LastAssignedAt = &lastAssignedAt.Time
+		}
+
+		duties = append(duties, duty)
+	}
+
+	return duties, rows.Err()
+}
+
+// MarkAssigned records that a staff member on duty was given a ticket
+//
+// Returns: an error if any
+func (r *sqlDutyRepository) MarkAssigned(userID discord.UserID, at time.Time) error {
+	_, err := r.db.Exec("UPDATE staff_duty SET last_assigned_at = ? WHERE user_id = ?", at.UTC(), int64(userID))
+
+	return err
+}
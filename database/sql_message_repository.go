@@ -0,0 +1,152 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// sqlMessageRepository reads and writes ticket messages to the ticket_messages table
+type sqlMessageRepository struct {
+	db *sql.DB
+}
+
+const messageColumns = "id, ticket_id, message_id, author_id, author_name, direction, content, attachments, created_at, edited_at, deleted_at"
+
+// scanMessage scans a single ticket message row into a TicketMessage struct
+func scanMessage(row scanner) (*TicketMessage, error) {
+	var (
+		message     TicketMessage
+		messageID   sql.NullInt64
+		authorID    int64
+		attachments string
+		editedAt    sql.NullTime
+		deletedAt   sql.NullTime
+	)
+
+	err := row.Scan(&message.ID, &message.TicketID, &messageID, &authorID, &message.AuthorName, &message.Direction, &message.Content, &attachments, &message.CreatedAt, &editedAt, &deletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if messageID.Valid {
+		message.MessageID = discord.MessageID(messageID.Int64)
+	}
+
+	message.AuthorID = discord.UserID(authorID)
+
+	if editedAt.Valid {
+		message.EditedAt = &editedAt.Time
+	}
+
+	if deletedAt.Valid {
+		message.DeletedAt = &deletedAt.Time
+	}
+
+	if err := json.Unmarshal([]byte(attachments), &message.Attachments); err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// Create inserts a new message and sets its ID
+//
+// Returns: an error if any
+func (r *sqlMessageRepository) Create(message *TicketMessage) error {
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
+
+	attachments, err := json.Marshal(message.Attachments)
+	if err != nil {
+		return err
+	}
+
+	var messageID sql.NullInt64
+	if message.MessageID.IsValid() {
+		messageID = sql.NullInt64{Int64: int64(message.MessageID), Valid: true}
+	}
+
+	result, err := r.db.Exec(
+		"INSERT INTO ticket_messages (ticket_id, message_id, author_id, author_name, direction, content, attachments, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		message.TicketID, messageID, int64(message.AuthorID), message.AuthorName, message.Direction, message.Content, string(attachments), message.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	message.ID, err = result.LastInsertId()
+
+	return err
+}
+
+// ListByTicket lists all messages of a ticket, oldest first
+//
+// Returns: a slice of TicketMessage and an error if any
+func (r *sqlMessageRepository) ListByTicket(ticketID int64) ([]TicketMessage, error) {
+	rows, err := r.db.Query("SELECT "+messageColumns+" FROM ticket_messages WHERE ticket_id = ? ORDER BY id", ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []TicketMessage
+	for rows.Next() {
+		message, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, *message)
+	}
+
+	return messages, rows.Err()
+}
+
+// FindByMessageID finds a message by its Discord message ID, returning nil if none exists
+//
+// Returns: a pointer to a TicketMessage and an error if any
+func (r *sqlMessageRepository) FindByMessageID(messageID discord.MessageID) (*TicketMessage, error) {
+	message, err := scanMessage(r.db.QueryRow("SELECT "+messageColumns+" FROM ticket_messages WHERE message_id = ? ORDER BY id DESC LIMIT 1", int64(messageID)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return message, err
+}
+
+// FindLatestReply finds the latest staff reply of an author in a ticket that was not deleted, returning nil if none exists
+//
+// Returns: a pointer to a TicketMessage and an error if any
+func (r *sqlMessageRepository) FindLatestReply(ticketID int64, authorID discord.UserID) (*TicketMessage, error) {
+	message, err := scanMessage(r.db.QueryRow(
+		"SELECT "+messageColumns+" FROM ticket_messages WHERE ticket_id = ? AND author_id = ? AND direction = ? AND deleted_at IS NULL ORDER BY id DESC LIMIT 1",
+		ticketID, int64(authorID), MessageOutbound,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return message, err
+}
+
+// Edit replaces the content of a message and records when it was edited
+//
+// Returns: an error if any
+func (r *sqlMessageRepository) Edit(id int64, content string, at time.Time) error {
+	_, err := r.db.Exec("UPDATE ticket_messages SET content = ?, edited_at = ? WHERE id = ?", content, at, id)
+
+	return err
+}
+
+// MarkDeleted records that a message was deleted
+//
+// Returns: an error if any
+func (r *sqlMessageRepository) MarkDeleted(id int64, at time.Time) error {
+	_, err := r.db.Exec("UPDATE ticket_messages SET deleted_at = ? WHERE id = ?", at, id)
+
+	return err
+}
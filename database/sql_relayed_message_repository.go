@@ -0,0 +1,87 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// sqlRelayedMessageRepository reads and writes relayed message copies to the relayed_messages table
+type sqlRelayedMessageRepository struct {
+	db *sql.DB
+}
+
+// Create inserts a new relayed message and sets its ID
+//
+// Returns: an error if any
+func (r *sqlRelayedMessageRepository) Create(relayed *RelayedMessage) error {
+	if relayed.CreatedAt.IsZero() {
+		relayed.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.Exec(
+		"INSERT INTO relayed_messages (ticket_id, source_message_id, channel_id, message_id, created_at) VALUES (?, ?, ?, ?, ?)",
+		relayed.TicketID, int64(relayed.SourceMessageID), int64(relayed.ChannelID), int64(relayed.MessageID), relayed.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	relayed.ID, err = result.LastInsertId()
+
+	return err
+}
+
+// ListBySource lists all copies of a source message, oldest first
+//
+// Returns: a slice of RelayedMessage and an error if any
+func (r *sqlRelayedMessageRepository) ListBySource(sourceMessageID discord.MessageID) ([]RelayedMessage, error) {
+	rows, err := r.db.Query(
+		"SELECT id, ticket_id, source_message_id, channel_id, message_id, created_at FROM relayed_messages WHERE source_message_id = ? ORDER BY id",
+		int64(sourceMessageID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var copies []RelayedMessage
+	for rows.Next() {
+		var (
+			relayed   RelayedMessage
+			sourceID  int64
+			channelID int64
+			messageID int64
+		)
+
+		if err := rows.Scan(&relayed.ID, &relayed.TicketID, &sourceID, &channelID, &messageID, &relayed.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		relayed.SourceMessageID = discord.MessageID(sourceID)
+		relayed.ChannelID = discord.ChannelID(channelID)
+		relayed.MessageID = discord.MessageID(messageID)
+		copies = append(copies, relayed)
+	}
+
+	return copies, rows.Err()
+}
+
+// DeleteBySource removes all copies of a source message
+//
+// Returns: an error if any
+func (r *sqlRelayedMessageRepository) DeleteBySource(sourceMessageID discord.MessageID) error {
+	_, err := r.db.Exec("DELETE FROM relayed_messages WHERE source_message_id = ?", int64(sourceMessageID))
+
+	return err
+}
+
+// DeleteByMessage removes a single copy by the ID of the copied message
+//
+// Returns: an error if any
+func (r *sqlRelayedMessageRepository) DeleteByMessage(messageID discord.MessageID) error {
+	_, err := r.db.Exec("DELETE FROM relayed_messages WHERE message_id = ?", int64(messageID))
+
+	return err
+}
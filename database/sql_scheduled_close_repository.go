@@ -0,0 +1,115 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// sqlScheduledCloseRepository reads and writes scheduled closes to the scheduled_closes table.
+// Times are stored in UTC so they compare correctly on every backend.
+type sqlScheduledCloseRepository struct {
+	db *sql.DB
+}
+
+const scheduledCloseColumns = "ticket_id, close_at, scheduled_by, reason, silent, created_at"
+
+// scanScheduledClose scans a single scheduled close row into a ScheduledClose struct
+func scanScheduledClose(row scanner) (*ScheduledClose, error) {
+	var (
+		scheduled   ScheduledClose
+		scheduledBy int64
+		reason      sql.NullString
+	)
+
+	err := row.Scan(&scheduled.TicketID, &scheduled.CloseAt, &scheduledBy, &reason, &scheduled.Silent, &scheduled.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled.ScheduledBy = discord.UserID(scheduledBy)
+	scheduled.Reason = reason.String
+
+	return &scheduled, nil
+}
+
+// Schedule schedules a ticket to be closed, replacing any earlier schedule for it
+//
+// Returns: an error if any
+func (r *sqlScheduledCloseRepository) Schedule(scheduled *ScheduledClose) error {
+	if scheduled.CreatedAt.IsZero() {
+		scheduled.CreatedAt = time.Now()
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM scheduled_closes WHERE ticket_id = ?", scheduled.TicketID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO scheduled_closes (ticket_id, close_at, scheduled_by, reason, silent, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+		scheduled.TicketID, scheduled.CloseAt.UTC(), int64(scheduled.ScheduledBy), sql.NullString{String: scheduled.Reason, Valid: scheduled.Reason != ""}, scheduled.Silent, scheduled.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Cancel cancels the scheduled close of a ticket
+//
+// Returns: whether a scheduled close existed and an error if any
+func (r *sqlScheduledCloseRepository) Cancel(ticketID int64) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM scheduled_closes WHERE ticket_id = ?", ticketID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+
+	return affected > 0, err
+}
+
+// FindByTicket finds the scheduled close of a ticket
+//
+// Returns: a pointer to the ScheduledClose (nil if none exists) and an error if any
+func (r *sqlScheduledCloseRepository) FindByTicket(ticketID int64) (*ScheduledClose, error) {
+	row := r.db.QueryRow("SELECT "+scheduledCloseColumns+" FROM scheduled_closes WHERE ticket_id = ?", ticketID)
+
+	scheduled, err := scanScheduledClose(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return scheduled, err
+}
+
+// ListDue lists all scheduled closes that are due at the given time
+//
+// Returns: a slice of ScheduledClose and an error if any
+func (r *sqlScheduledCloseRepository) ListDue(now time.Time) ([]ScheduledClose, error) {
+	rows, err := r.db.Query("SELECT "+scheduledCloseColumns+" FROM scheduled_closes WHERE close_at <= ? ORDER BY close_at", now.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var closes []ScheduledClose
+	for rows.Next() {
+		scheduled, err := scanScheduledClose(rows)
+		if err != nil {
+			return nil, err
+		}
+		closes = append(closes, *scheduled)
+	}
+
+	return closes, rows.Err()
+}
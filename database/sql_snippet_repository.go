@@ -0,0 +1,115 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// sqlSnippetRepository reads and writes snippets to the snippets table
+type sqlSnippetRepository struct {
+	db *sql.DB
+}
+
+const snippetColumns = "id, name, content, created_by, created_at, updated_at"
+
+// scanSnippet scans a single snippet row into a Snippet struct
+func scanSnippet(row scanner) (*Snippet, error) {
+	var (
+		snippet   Snippet
+		createdBy int64
+	)
+
+	if err := row.Scan(&snippet.ID, &snippet.Name, &snippet.Content, &createdBy, &snippet.CreatedAt, &snippet.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	snippet.CreatedBy = discord.UserID(createdBy)
+
+	return &snippet, nil
+}
+
+// Create inserts a new snippet and sets its ID
+//
+// Returns: an error if any
+func (r *sqlSnippetRepository) Create(snippet *Snippet) error {
+	now := time.Now()
+	snippet.CreatedAt = now
+	snippet.UpdatedAt = now
+
+	result, err := r.db.Exec(
+		"INSERT INTO snippets (name, content, created_by, created_at, updated_at) VALUES (?, ?, ?, ?, ?)",
+		snippet.Name, snippet.Content, int64(snippet.CreatedBy), now, now,
+	)
+	if err != nil {
+		return err
+	}
+
+	snippet.ID, err = result.LastInsertId()
+
+	return err
+}
+
+// Update replaces the content of a snippet
+//
+// Returns: whether the snippet existed and an error if any
+func (r *sqlSnippetRepository) Update(name string, content string) (bool, error) {
+	result, err := r.db.Exec("UPDATE snippets SET content = ?, updated_at = ? WHERE name = ?", content, time.Now(), name)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+
+	return affected > 0, err
+}
+
+// Delete removes a snippet
+//
+// Returns: whether the snippet existed and an error if any
+func (r *sqlSnippetRepository) Delete(name string) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM snippets WHERE name = ?", name)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+
+	return affected > 0, err
+}
+
+// FindByName finds a snippet by its name, returning nil if none exists
+//
+// Returns: a pointer to a Snippet and an error if any
+func (r *sqlSnippetRepository) FindByName(name string) (*Snippet, error) {
+	snippet, err := scanSnippet(r.db.QueryRow("SELECT "+snippetColumns+" FROM snippets WHERE name = ?", name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return snippet, err
+}
+
+// List lists all snippets ordered by name
+//
+// Returns: a slice of Snippet and an error if any
+func (r *sqlSnippetRepository) List() ([]Snippet, error) {
+	rows, err := r.db.Query("SELECT " + snippetColumns + " FROM snippets ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snippets []Snippet
+	for rows.Next() {
+		snippet, err := scanSnippet(rows)
+		if err != nil {
+			return nil, err
+		}
+		snippets = append(snippets, *snippet)
+	}
+
+	return snippets, rows.Err()
+}
@@ -0,0 +1,82 @@
+package database
+
+import "database/sql"
+
+// sqlStore is a Store backed by a database/sql connection pool. The queries
+// are written to run unchanged on both MySQL and SQLite.
+type sqlStore struct {
+	db       *sql.DB
+	tickets  *sqlTicketRepository
+	messages *sqlMessageRepository
+	closes   *sqlScheduledCloseRepository
+	relayed  *sqlRelayedMessageRepository
+	snippets *sqlSnippetRepository
+	blocks   *sqlBlockRepository
+	duties   *sqlDutyRepository
+	tags     *sqlTagRepository
+}
+
+// NewSQLStore creates a new Store backed by the given connection pool
+func NewSQLStore(db *sql.DB) Store {
+	return &sqlStore{
+		db:       db,
+		tickets:  &sqlTicketRepository{db: db},
+		messages: &sqlMessageRepository{db: db},
+		closes:   &sqlScheduledCloseRepository{db: db},
+		relayed:  &sqlRelayedMessageRepository{db: db},
+		snippets: &sqlSnippetRepository{db: db},
+		blocks:   &sqlBlockRepository{db: db},
+		duties:   &sqlDutyRepository{db: db},
+		tags:     &sqlTagRepository{db: db},
+	}
+}
+
+// Tickets returns the ticket repository
+func (s *sqlStore) Tickets() TicketRepository {
+	return s.tickets
+}
+
+// Messages returns the ticket message repository
+func (s *sqlStore) Messages() MessageRepository {
+	return s.messages
+}
+
+// ScheduledCloses returns the scheduled close repository
+func (s *sqlStore) ScheduledCloses() ScheduledCloseRepository {
+	return s.closes
+}
+
+// RelayedMessages returns the relayed message repository
+func (s *sqlStore) RelayedMessages() RelayedMessageRepository {
+	return s.relayed
+}
+
+// Snippets returns the snippet repository
+func (s *sqlStore) Snippets() SnippetRepository {
+	return s.snippets
+}
+
+// Blocks returns the block repository
+func (s *sqlStore) Blocks() BlockRepository {
+	return s.blocks
+}
+
+// Duties returns the duty repository
+func (s *sqlStore) Duties() DutyRepository {
+	return s.duties
+}
+
+// Tags returns the tag repository
+func (s *sqlStore) Tags() TagRepository {
+	return s.tags
+}
+
+// Close closes the underlying connection pool
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
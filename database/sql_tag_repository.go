@@ -0,0 +1,114 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// sqlTagRepository reads and writes the tags of tickets to the ticket_tags table
+type sqlTagRepository struct {
+	db *sql.DB
+}
+
+// Add tags a ticket
+//
+// Returns: whether the ticket didn't have the tag yet and an error if any
+func (r *sqlTagRepository) Add(ticketID int64, tag string) (bool, error) {
+	var count int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM ticket_tags WHERE ticket_id = ? AND tag = ?", ticketID, tag).Scan(&count); err != nil {
+		return false, err
+	}
+
+	if count > 0 {
+		return false, nil
+	}
+
+	if _, err := r.db.Exec("INSERT INTO ticket_tags (ticket_id, tag, created_at) VALUES (?, ?, ?)", ticketID, tag, time.Now()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Remove removes a tag from a ticket
+//
+// Returns: whether the ticket had the tag and an error if any
+func (r *sqlTagRepository) Remove(ticketID int64, tag string) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM ticket_tags WHERE ticket_id = ? AND tag = ?", ticketID, tag)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+
+	return affected > 0, err
+}
+
+// ListByTicket lists the tags of a ticket in alphabetical order
+//
+// Returns: a slice of tags and an error if any
+func (r *sqlTagRepository) ListByTicket(ticketID int64) ([]string, error) {
+	rows, err := r.db.Query("SELECT tag FROM ticket_tags WHERE ticket_id = ? ORDER BY tag", ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// Count counts the tickets of every tag in use, most used first
+//
+// Returns: a slice of TagCount and an error if any
+func (r *sqlTagRepository) Count() ([]TagCount, error) {
+	rows, err := r.db.Query("SELECT tag, COUNT(*) AS uses FROM ticket_tags GROUP BY tag ORDER BY uses DESC, tag")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []TagCount
+	for rows.Next() {
+		var count TagCount
+		if err := rows.Scan(&count.Tag, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
+
+// FindTickets lists the tickets with a tag, newest first and at most limit of them
+//
+// Returns: a slice of Ticket and an error if any
+func (r *sqlTagRepository) FindTickets(tag string, limit int) ([]Ticket, error) {
+	rows, err := r.db.Query(
+		"SELECT "+ticketColumns+" FROM tickets WHERE id IN (SELECT ticket_id FROM ticket_tags WHERE tag = ?) ORDER BY id DESC LIMIT ?",
+		tag, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []Ticket
+	for rows.Next() {
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, *ticket)
+	}
+
+	return tickets, rows.Err()
+}
@@ -0,0 +1,293 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// sqlTicketRepository reads and writes tickets to the tickets table
+type sqlTicketRepository struct {
+	db *sql.DB
+}
+
+const ticketColumns = "id, user_id, channel_id, status, created_at, updated_at, closed_at, closed_by, close_reason, last_activity_at, inactivity_warned_at, claimed_by, priority, ticket_type"
+
+// scanTicket scans a single ticket row into a Ticket struct
+func scanTicket(row scanner) (*Ticket, error) {
+	var (
+		ticket    Ticket
+		userID    int64
+		channelID int64
+		closedAt  sql.NullTime
+		closedBy  sql.NullInt64
+		reason    sql.NullString
+		warnedAt  sql.NullTime
+		claimedBy sql.NullInt64
+		kind      sql.NullString
+	)
+
+	err := row.Scan(&ticket.ID, &userID, &channelID, &ticket.Status, &ticket.CreatedAt, &ticket.UpdatedAt, &closedAt, &closedBy, &reason, &ticket.LastActivityAt, &warnedAt, &claimedBy, &ticket.Priority, &kind)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket.UserID = discord.UserID(userID)
+	ticket.ChannelID = discord.ChannelID(channelID)
+
+	if closedAt.Valid {
+		ticket.ClosedAt = &closedAt.Time
+	}
+
+	if closedBy.Valid {
+		ticket.ClosedBy = discord.UserID(closedBy.Int64)
+	}
+
+	ticket.CloseReason = reason.String
+
+	if warnedAt.Valid {
+		ticket.InactivityWarnedAt = &warnedAt.Time
+	}
+
+	if claimedBy.Valid {
+		ticket.ClaimedBy = discord.UserID(claimedBy.Int64)
+	}
+
+	ticket.Type = kind.String
+
+	return &ticket, nil
+}
+
+// Create inserts a new open ticket for the given user and channel
+//
+// Returns: a pointer to the created Ticket and an error if any
+func (r *sqlTicketRepository) Create(userID discord.UserID, channelID discord.ChannelID) (*Ticket, error) {
+	now := time.Now()
+
+	result, err := r.db.Exec(
+		"INSERT INTO tickets (user_id, channel_id, status, created_at, updated_at, last_activity_at, priority) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		int64(userID), int64(channelID), TicketStatusOpen, now, now, now.UTC(), PriorityNormal,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ticket{
+		ID:             id,
+		UserID:         userID,
+		ChannelID:      channelID,
+		Status:         TicketStatusOpen,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		LastActivityAt: now,
+		Priority:       PriorityNormal,
+	}, nil
+}
+
+// FindByID finds a ticket by its ID
+//
+// Returns: a pointer to the Ticket (nil if none exists) and an error if any
+func (r *sqlTicketRepository) FindByID(id int64) (*Ticket, error) {
+	row := r.db.QueryRow("SELECT "+ticketColumns+" FROM tickets WHERE id = ?", id)
+
+	return findTicket(row)
+}
+
+// FindLatestByUser finds the most recent ticket of a user, open or closed
+//
+// Returns: a pointer to the Ticket (nil if none exists) and an error if any
+func (r *sqlTicketRepository) FindLatestByUser(userID discord.UserID) (*Ticket, error) {
+	row := r.db.QueryRow(
+		"SELECT "+ticketColumns+" FROM tickets WHERE user_id = ? ORDER BY id DESC LIMIT 1",
+		int64(userID),
+	)
+
+	return findTicket(row)
+}
+
+// FindOpenByUser finds the open ticket of a user
+//
+// Returns: a pointer to the Ticket (nil if none exists) and an error if any
+func (r *sqlTicketRepository) FindOpenByUser(userID discord.UserID) (*Ticket, error) {
+	row := r.db.QueryRow(
+		"SELECT "+ticketColumns+" FROM tickets WHERE user_id = ? AND status = ? ORDER BY id DESC LIMIT 1",
+		int64(userID), TicketStatusOpen,
+	)
+
+	return findTicket(row)
+}
+
+// FindOpenByChannel finds the open ticket that belongs to a channel
+//
+// Returns: a pointer to the Ticket (nil if none exists) and an error if any
+func (r *sqlTicketRepository) FindOpenByChannel(channelID discord.ChannelID) (*Ticket, error) {
+	row := r.db.QueryRow(
+		"SELECT "+ticketColumns+" FROM tickets WHERE channel_id = ? AND status = ? ORDER BY id DESC LIMIT 1",
+		int64(channelID), TicketStatusOpen,
+	)
+
+	return findTicket(row)
+}
+
+// FindLatestByChannel finds the most recent ticket of a channel, open or closed
+//
+// Returns: a pointer to the Ticket (nil if none exists) and an error if any
+func (r *sqlTicketRepository) FindLatestByChannel(channelID discord.ChannelID) (*Ticket, error) {
+	row := r.db.QueryRow(
+		"SELECT "+ticketColumns+" FROM tickets WHERE channel_id = ? ORDER BY id DESC LIMIT 1",
+		int64(channelID),
+	)
+
+	return findTicket(row)
+}
+
+// Close marks a ticket as closed by the given user. A zero closedBy means the
+// ticket was closed without a known staff member, e.g. the channel was deleted.
+// An empty reason is stored as NULL.
+//
+// Returns: an error if any
+func (r *sqlTicketRepository) Close(id int64, closedBy discord.UserID, reason string) error {
+	now := time.Now()
+
+	var closer sql.NullInt64
+	if closedBy.IsValid() {
+		closer = sql.NullInt64{Int64: int64(closedBy), Valid: true}
+	}
+
+	closeReason := sql.NullString{String: reason, Valid: reason != ""}
+
+	_, err := r.db.Exec(
+		"UPDATE tickets SET status = ?, closed_at = ?, closed_by = ?, close_reason = ?, updated_at = ? WHERE id = ? AND status = ?",
+		TicketStatusClosed, now, closer, closeReason, now, id, TicketStatusOpen,
+	)
+
+	return err
+}
+
+// Touch records activity on a ticket, which also resets any inactivity warning.
+// Activity times are stored in UTC so they compare correctly on every backend.
+//
+// Returns: an error if any
+func (r *sqlTicketRepository) Touch(id int64, at time.Time) error {
+	_, err := r.db.Exec(
+		"UPDATE tickets SET last_activity_at = ?, inactivity_warned_at = NULL WHERE id = ?",
+		at.UTC(), id,
+	)
+
+	return err
+}
+
+// MarkInactivityWarned records that the owner of a ticket was warned about inactivity
+//
+// Returns: an error if any
+func (r *sqlTicketRepository) MarkInactivityWarned(id int64, at time.Time) error {
+	_, err := r.db.Exec("UPDATE tickets SET inactivity_warned_at = ? WHERE id = ?", at.UTC(), id)
+
+	return err
+}
+
+// ListInactiveSince lists the open tickets without any activity since the given time
+//
+// Returns: a slice of Ticket and an error if any
+func (r *sqlTicketRepository) ListInactiveSince(since time.Time) ([]Ticket, error) {
+	rows, err := r.db.Query(
+		"SELECT "+ticketColumns+" FROM tickets WHERE status = ? AND last_activity_at <= ? ORDER BY last_activity_at",
+		TicketStatusOpen, since.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []Ticket
+	for rows.Next() {
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, *ticket)
+	}
+
+	return tickets, rows.Err()
+}
+
+// Assign assigns a ticket to a staff member. A zero staffID releases the ticket
+// and is stored as NULL.
+//
+// Returns: an error if any
+func (r *sqlTicketRepository) Assign(id int64, staffID discord.UserID) error {
+	var claimer sql.NullInt64
+	if staffID.IsValid() {
+		claimer = sql.NullInt64{Int64: int64(staffID), Valid: true}
+	}
+
+	_, err := r.db.Exec("UPDATE tickets SET claimed_by = ?, updated_at = ? WHERE id = ?", claimer, time.Now(), id)
+
+	return err
+}
+
+// SetPriority changes the priority of a ticket
+//
+// Returns: an error if any
+func (r *sqlTicketRepository) SetPriority(id int64, priority TicketPriority) error {
+	_, err := r.db.Exec("UPDATE tickets SET priority = ?, updated_at = ? WHERE id = ?", priority, time.Now(), id)
+
+	return err
+}
+
+// SetType records the ticket type the user chose for a ticket, an empty type is stored as NULL
+//
+// Returns: an error if any
+func (r *sqlTicketRepository) SetType(id int64, ticketType string) error {
+	_, err := r.db.Exec(
+		"UPDATE tickets SET ticket_type = ?, updated_at = ? WHERE id = ?",
+		sql.NullString{String: ticketType, Valid: ticketType != ""}, time.Now(), id,
+	)
+
+	return err
+}
+
+// CountOpenByClaimer counts the open tickets of every staff member that claimed at least one
+//
+// Returns: the number of open tickets keyed by staff member and an error if any
+func (r *sqlTicketRepository) CountOpenByClaimer() (map[discord.UserID]int, error) {
+	rows, err := r.db.Query(
+		"SELECT claimed_by, COUNT(*) FROM tickets WHERE status = ? AND claimed_by IS NOT NULL GROUP BY claimed_by",
+		TicketStatusOpen,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[discord.UserID]int)
+	for rows.Next() {
+		var (
+			claimedBy int64
+			count     int
+		)
+		if err := rows.Scan(&claimedBy, &count); err != nil {
+			return nil, err
+		}
+		counts[discord.UserID(claimedBy)] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// findTicket scans a single ticket, mapping sql.ErrNoRows to a nil ticket
+func findTicket(row *sql.Row) (*Ticket, error) {
+	ticket, err := scanTicket(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return ticket, err
+}
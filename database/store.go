@@ -0,0 +1,157 @@
+package database
+
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// Store is the storage backend of the bot. Each backend (MySQL, SQLite and
+// in-memory) exposes the same repositories so the rest of the bot does not
+// need to know which one is in use.
+type Store interface {
+	// Tickets returns the ticket repository
+	Tickets() TicketRepository
+	// Messages returns the ticket message repository
+	Messages() MessageRepository
+	// ScheduledCloses returns the scheduled close repository
+	ScheduledCloses() ScheduledCloseRepository
+	// RelayedMessages returns the relayed message repository
+	RelayedMessages() RelayedMessageRepository
+	// Snippets returns the snippet repository
+	Snippets() SnippetRepository
+	// Blocks returns the block repository
+	Blocks() BlockRepository
+	// Duties returns the duty repository
+	Duties() DutyRepository
+	// Tags returns the tag repository
+	Tags() TagRepository
+	// Close releases any resources held by the store
+	Close() error
+}
+
+// TicketRepository reads and writes tickets
+type TicketRepository interface {
+	// Create inserts a new open ticket for the given user and channel
+	Create(userID discord.UserID, channelID discord.ChannelID) (*Ticket, error)
+	// FindByID finds a ticket by its ID, returning nil if none exists
+	FindByID(id int64) (*Ticket, error)
+	// FindLatestByUser finds the most recent ticket of a user, open or closed, returning nil if none exists
+	FindLatestByUser(userID discord.UserID) (*Ticket, error)
+	// FindOpenByUser finds the open ticket of a user, returning nil if none exists
+	FindOpenByUser(userID discord.UserID) (*Ticket, error)
+	// FindOpenByChannel finds the open ticket of a channel, returning nil if none exists
+	FindOpenByChannel(channelID discord.ChannelID) (*Ticket, error)
+	// FindLatestByChannel finds the most recent ticket of a channel, open or closed, returning nil if none exists
+	FindLatestByChannel(channelID discord.ChannelID) (*Ticket, error)
+	// Close marks a ticket as closed by the given user with an optional reason
+	Close(id int64, closedBy discord.UserID, reason string) error
+	// Touch records activity on a ticket, which also resets any inactivity warning
+	Touch(id int64, at time.Time) error
+	// MarkInactivityWarned records that the owner of a ticket was warned about inactivity
+	MarkInactivityWarned(id int64, at time.Time) error
+	// ListInactiveSince lists the open tickets without any activity since the given time
+	ListInactiveSince(since time.Time) ([]Ticket, error)
+	// Assign assigns a ticket to a staff member, a zero staffID releases the ticket
+	Assign(id int64, staffID discord.UserID) error
+	// SetPriority changes the priority of a ticket
+	SetPriority(id int64, priority TicketPriority) error
+	// SetType records the ticket type the user chose for a ticket
+	SetType(id int64, ticketType string) error
+	// CountOpenByClaimer counts the open tickets of every staff member that claimed at least one
+	CountOpenByClaimer() (map[discord.UserID]int, error)
+}
+
+// MessageRepository reads and writes the message log of tickets
+type MessageRepository interface {
+	// Create inserts a new message and sets its ID
+	Create(message *TicketMessage) error
+	// ListByTicket lists all messages of a ticket, oldest first
+	ListByTicket(ticketID int64) ([]TicketMessage, error)
+	// FindByMessageID finds a message by its Discord message ID, returning nil if none exists
+	FindByMessageID(messageID discord.MessageID) (*TicketMessage, error)
+	// FindLatestReply finds the latest staff reply of an author in a ticket that was not deleted, returning nil if none exists
+	FindLatestReply(ticketID int64, authorID discord.UserID) (*TicketMessage, error)
+	// Edit replaces the content of a message and records when it was edited
+	Edit(id int64, content string, at time.Time) error
+	// MarkDeleted records that a message was deleted
+	MarkDeleted(id int64, at time.Time) error
+}
+
+// RelayedMessageRepository reads and writes the copies the bot sent of relayed messages
+type RelayedMessageRepository interface {
+	// Create inserts a new relayed message and sets its ID
+	Create(relayed *RelayedMessage) error
+	// ListBySource lists all copies of a source message, oldest first
+	ListBySource(sourceMessageID discord.MessageID) ([]RelayedMessage, error)
+	// DeleteBySource removes all copies of a source message
+	DeleteBySource(sourceMessageID discord.MessageID) error
+	// DeleteByMessage removes a single copy by the ID of the copied message
+	DeleteByMessage(messageID discord.MessageID) error
+}
+
+// ScheduledCloseRepository reads and writes scheduled ticket closes
+type ScheduledCloseRepository interface {
+	// Schedule schedules a ticket to be closed, replacing any earlier schedule for it
+	Schedule(scheduled *ScheduledClose) error
+	// Cancel cancels the scheduled close of a ticket, reporting whether one existed
+	Cancel(ticketID int64) (bool, error)
+	// FindByTicket finds the scheduled close of a ticket, returning nil if none exists
+	FindByTicket(ticketID int64) (*ScheduledClose, error)
+	// ListDue lists all scheduled closes that are due at the given time
+	ListDue(now time.Time) ([]ScheduledClose, error)
+}
+
+// SnippetRepository reads and writes snippets
+type SnippetRepository interface {
+	// Create inserts a new snippet and sets its ID
+	Create(snippet *Snippet) error
+	// Update replaces the content of a snippet, reporting whether it existed
+	Update(name string, content string) (bool, error)
+	// Delete removes a snippet, reporting whether it existed
+	Delete(name string) (bool, error)
+	// FindByName finds a snippet by its name, returning nil if none exists
+	FindByName(name string) (*Snippet, error)
+	// List lists all snippets ordered by name
+	List() ([]Snippet, error)
+}
+
+// BlockRepository reads and writes blocked users
+type BlockRepository interface {
+	// Block blocks a user, replacing any earlier block of them
+	Block(block *Block) error
+	// Unblock lifts the block of a user, reporting whether one existed
+	Unblock(userID discord.UserID) (bool, error)
+	// FindActive finds the block of a user that is in effect at the given time, returning nil if none is
+	FindActive(userID discord.UserID, now time.Time) (*Block, error)
+	// ListActive lists all blocks in effect at the given time, oldest first
+	ListActive(now time.Time) ([]Block, error)
+	// DeleteExpired removes all blocks that expired before the given time, reporting how many were removed
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// DutyRepository reads and writes the staff members on duty
+type DutyRepository interface {
+	// Start puts a staff member on duty, reporting whether they were off duty before
+	Start(userID discord.UserID, at time.Time) (bool, error)
+	// Stop takes a staff member off duty, reporting whether they were on duty
+	Stop(userID discord.UserID) (bool, error)
+	// List lists all staff members on duty, longest on duty first
+	List() ([]Duty, error)
+	// MarkAssigned records that a staff member on duty was given a ticket
+	MarkAssigned(userID discord.UserID, at time.Time) error
+}
+
+// TagRepository reads and writes the tags of tickets
+type TagRepository interface {
+	// Add tags a ticket, reporting whether the ticket didn't have the tag yet
+	Add(ticketID int64, tag string) (bool, error)
+	// Remove removes a tag from a ticket, reporting whether the ticket had it
+	Remove(ticketID int64, tag string) (bool, error)
+	// ListByTicket lists the tags of a ticket in alphabetical order
+	ListByTicket(ticketID int64) ([]string, error)
+	// Count counts the tickets of every tag in use, most used first
+	Count() ([]TagCount, error)
+	// FindTickets lists the tickets with a tag, newest first and at most limit of them
+	FindTickets(tag string, limit int) ([]Ticket, error)
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"discord-bot-tickets/config"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+func TestMain(m *testing.M) {
+	// Tests run from the package folder instead of the root of the module
+	migrationsDir = "./migrations"
+
+	os.Exit(m.Run())
+}
+
+// testStore is a backend the repository tests run against
+type testStore struct {
+	name string
+	open func(t *testing.T) Store
+}
+
+// testStores are all backends that can run without outside services
+var testStores = []testStore{
+	{name: "memory", open: func(t *testing.T) Store { return NewMemoryStore() }},
+	{name: "sqlite", open: openSQLiteStore},
+}
+
+// forEachStore runs a test against a fresh store of every backend
+func forEachStore(t *testing.T, test func(t *testing.T, store Store)) {
+	for _, backend := range testStores {
+		t.Run(backend.name, func(t *testing.T) {
+			test(t, backend.open(t))
+		})
+	}
+}
+
+// sqliteConfig is the configuration of a new SQLite database in a temporary folder
+func sqliteConfig(t *testing.T) *config.Config {
+	return &config.Config{
+		Storage: config.StorageConfig{
+			Driver:     config.StorageSQLite,
+			SQLitePath: filepath.Join(t.TempDir(), "tickets.db"),
+		},
+	}
+}
+
+// newMigrate creates a migrate instance for the database of the configuration
+func newMigrate(t *testing.T, cfg *config.Config) *migrate.Migrate {
+	sourceURL, databaseURL := migrationURLs(cfg)
+
+	m, err := migrate.New(sourceURL, databaseURL)
+	if err != nil {
+		t.Fatalf("create migrate instance: %v", err)
+	}
+	t.Cleanup(func() { m.Close() })
+
+	return m
+}
+
+// openSQLiteStore opens a store on a new, fully migrated SQLite database
+func openSQLiteStore(t *testing.T) Store {
+	cfg := sqliteConfig(t)
+
+	if err := newMigrate(t, cfg).Up(); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	store := NewSQLStore(db)
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+// sqliteTables lists the tables of an SQLite database besides the migration bookkeeping
+func sqliteTables(t *testing.T, cfg *config.Config) []string {
+	db, err := openDB(cfg)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'schema_migrations' ORDER BY name")
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("scan table: %v", err)
+		}
+		tables = append(tables, name)
+	}
+
+	return tables
+}
+
+func TestMigrationsRoundTrip(t *testing.T) {
+	cfg := sqliteConfig(t)
+	m := newMigrate(t, cfg)
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	migrated := sqliteTables(t, cfg)
+	if len(migrated) == 0 {
+		t.Fatal("migrate up created no tables")
+	}
+
+	if err := m.Down(); err != nil {
+		t.Fatalf("migrate down: %v", err)
+	}
+	if tables := sqliteTables(t, cfg); len(tables) != 0 {
+		t.Fatalf("tables left after migrate down: %v", tables)
+	}
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("migrate up again: %v", err)
+	}
+	if tables := sqliteTables(t, cfg); !slices.Equal(tables, migrated) {
+		t.Fatalf("tables after migrating up again = %v, want %v", tables, migrated)
+	}
+
+	if err := m.Up(); !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("migrate up on a migrated database = %v, want %v", err, migrate.ErrNoChange)
+	}
+}
+
+func TestMigrationsMatchAcrossDrivers(t *testing.T) {
+	var want []string
+
+	for _, driver := range migrationDrivers {
+		entries, err := os.ReadDir(filepath.Join(migrationsDir, string(driver)))
+		if err != nil {
+			t.Fatalf("read %s migrations: %v", driver, err)
+		}
+
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+
+		if want == nil {
+			want = names
+			continue
+		}
+
+		if !slices.Equal(names, want) {
+			t.Errorf("%s migrations = %v, want the same files as %s: %v", driver, names, migrationDrivers[0], want)
+		}
+	}
+}
+
+func TestNextMigrationVersion(t *testing.T) {
+	version, err := nextMigrationVersion()
+	if err != nil {
+		t.Fatalf("nextMigrationVersion: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(migrationsDir, string(config.StorageMySQL)))
+	if err != nil {
+		t.Fatalf("read migrations: %v", err)
+	}
+
+	// Every version has an up and a down file
+	if want := len(entries)/2 + 1; version != want {
+		t.Fatalf("nextMigrationVersion = %d, want %d", version, want)
+	}
+}
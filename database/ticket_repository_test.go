@@ -0,0 +1,339 @@
+package database
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// mustCreateTicket creates an open ticket or fails the test
+func mustCreateTicket(t *testing.T, store Store, userID discord.UserID, channelID discord.ChannelID) *Ticket {
+	t.Helper()
+
+	ticket, err := store.Tickets().Create(userID, channelID)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	return ticket
+}
+
+// ticketIDs gets the IDs of tickets, in order
+func ticketIDs(tickets []Ticket) []int64 {
+	ids := make([]int64, 0, len(tickets))
+	for _, ticket := range tickets {
+		ids = append(ids, ticket.ID)
+	}
+
+	return ids
+}
+
+func TestTicketRepositoryCreate(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		created := mustCreateTicket(t, store, 1, 10)
+
+		found, err := store.Tickets().FindByID(created.ID)
+		if err != nil || found == nil {
+			t.Fatalf("FindByID = %v, %v", found, err)
+		}
+
+		if found.UserID != 1 || found.ChannelID != 10 {
+			t.Errorf("ticket belongs to user %d in channel %d, want user 1 in channel 10", found.UserID, found.ChannelID)
+		}
+		if !found.IsOpen() {
+			t.Errorf("status = %s, want %s", found.Status, TicketStatusOpen)
+		}
+		if found.Priority != PriorityNormal {
+			t.Errorf("priority = %s, want %s", found.Priority, PriorityNormal)
+		}
+		if found.ClaimedBy.IsValid() || found.Type != "" || found.ClosedAt != nil {
+			t.Errorf("new ticket has claimer %d, type %q, closed at %v, want none", found.ClaimedBy, found.Type, found.ClosedAt)
+		}
+
+		missing, err := store.Tickets().FindByID(created.ID + 100)
+		if err != nil || missing != nil {
+			t.Errorf("FindByID of a missing ticket = %v, %v, want nil", missing, err)
+		}
+	})
+}
+
+func TestTicketRepositoryFind(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		first := mustCreateTicket(t, store, 1, 10)
+		if err := store.Tickets().Close(first.ID, 99, "resolved"); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		second := mustCreateTicket(t, store, 1, 11)
+		other := mustCreateTicket(t, store, 2, 12)
+
+		tests := []struct {
+			name string
+			find func() (*Ticket, error)
+			want int64
+		}{
+			{"open ticket of user", func() (*Ticket, error) { return store.Tickets().FindOpenByUser(1) }, second.ID},
+			{"open ticket of other user", func() (*Ticket, error) { return store.Tickets().FindOpenByUser(2) }, other.ID},
+			{"open ticket of user without one", func() (*Ticket, error) { return store.Tickets().FindOpenByUser(3) }, 0},
+			{"open ticket of channel", func() (*Ticket, error) { return store.Tickets().FindOpenByChannel(11) }, second.ID},
+			{"open ticket of closed channel", func() (*Ticket, error) { return store.Tickets().FindOpenByChannel(10) }, 0},
+			{"latest ticket of closed channel", func() (*Ticket, error) { return store.Tickets().FindLatestByChannel(10) }, first.ID},
+			{"latest ticket of open channel", func() (*Ticket, error) { return store.Tickets().FindLatestByChannel(11) }, second.ID},
+			{"latest ticket of channel without one", func() (*Ticket, error) { return store.Tickets().FindLatestByChannel(99) }, 0},
+			{"latest ticket of user", func() (*Ticket, error) { return store.Tickets().FindLatestByUser(1) }, second.ID},
+			{"latest ticket of user without one", func() (*Ticket, error) { return store.Tickets().FindLatestByUser(3) }, 0},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				found, err := tt.find()
+				if err != nil {
+					t.Fatalf("find: %v", err)
+				}
+
+				var got int64
+				if found != nil {
+					got = found.ID
+				}
+				if got != tt.want {
+					t.Errorf("found ticket %d, want %d", got, tt.want)
+				}
+			})
+		}
+	})
+}
+
+func TestTicketRepositoryClose(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ticket := mustCreateTicket(t, store, 1, 10)
+
+		if err := store.Tickets().Close(ticket.ID, 99, "resolved"); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		closed, err := store.Tickets().FindByID(ticket.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+
+		if closed.IsOpen() || closed.ClosedAt == nil {
+			t.Errorf("status = %s, closed at %v, want a closed ticket", closed.Status, closed.ClosedAt)
+		}
+		if closed.ClosedBy != 99 || closed.CloseReason != "resolved" {
+			t.Errorf("closed by %d for %q, want 99 for %q", closed.ClosedBy, closed.CloseReason, "resolved")
+		}
+	})
+}
+
+func TestTicketRepositoryUpdates(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ticket := mustCreateTicket(t, store, 1, 10)
+
+		if err := store.Tickets().Assign(ticket.ID, 50); err != nil {
+			t.Fatalf("Assign: %v", err)
+		}
+		if err := store.Tickets().SetPriority(ticket.ID, PriorityUrgent); err != nil {
+			t.Fatalf("SetPriority: %v", err)
+		}
+		if err := store.Tickets().SetType(ticket.ID, "appeals"); err != nil {
+			t.Fatalf("SetType: %v", err)
+		}
+
+		updated, err := store.Tickets().FindByID(ticket.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if updated.ClaimedBy != 50 || updated.Priority != PriorityUrgent || updated.Type != "appeals" {
+			t.Errorf("claimer %d, priority %s, type %q, want 50, %s, %q", updated.ClaimedBy, updated.Priority, updated.Type, PriorityUrgent, "appeals")
+		}
+
+		// A zero staff member releases the ticket, and an empty type clears it
+		if err := store.Tickets().Assign(ticket.ID, discord.NullUserID); err != nil {
+			t.Fatalf("Assign: %v", err)
+		}
+		if err := store.Tickets().SetType(ticket.ID, ""); err != nil {
+			t.Fatalf("SetType: %v", err)
+		}
+
+		released, err := store.Tickets().FindByID(ticket.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if released.ClaimedBy.IsValid() || released.Type != "" {
+			t.Errorf("claimer %d, type %q after releasing, want none", released.ClaimedBy, released.Type)
+		}
+	})
+}
+
+func TestTicketRepositoryInactivity(t *testing.T) {
+	now := time.Now()
+
+	forEachStore(t, func(t *testing.T, store Store) {
+		stale := mustCreateTicket(t, store, 1, 10)
+		idle := mustCreateTicket(t, store, 2, 11)
+		active := mustCreateTicket(t, store, 3, 12)
+		closed := mustCreateTicket(t, store, 4, 13)
+
+		touches := map[int64]time.Time{
+			stale.ID:  now.Add(-72 * time.Hour),
+			idle.ID:   now.Add(-36 * time.Hour),
+			active.ID: now.Add(-time.Hour),
+			closed.ID: now.Add(-96 * time.Hour),
+		}
+		for id, at := range touches {
+			if err := store.Tickets().Touch(id, at); err != nil {
+				t.Fatalf("Touch: %v", err)
+			}
+		}
+		if err := store.Tickets().Close(closed.ID, 99, ""); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		tests := []struct {
+			name  string
+			since time.Time
+			want  []int64
+		}{
+			{"a day", now.Add(-24 * time.Hour), []int64{stale.ID, idle.ID}},
+			{"two days", now.Add(-48 * time.Hour), []int64{stale.ID}},
+			{"a week", now.Add(-168 * time.Hour), []int64{}},
+			{"now", now, []int64{stale.ID, idle.ID, active.ID}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				inactive, err := store.Tickets().ListInactiveSince(tt.since)
+				if err != nil {
+					t.Fatalf("ListInactiveSince: %v", err)
+				}
+
+				if got := ticketIDs(inactive); !slices.Equal(got, tt.want) {
+					t.Errorf("inactive tickets = %v, want %v", got, tt.want)
+				}
+			})
+		}
+
+		// A warning is reset by new activity
+		if err := store.Tickets().MarkInactivityWarned(stale.ID, now); err != nil {
+			t.Fatalf("MarkInactivityWarned: %v", err)
+		}
+		warned, _ := store.Tickets().FindByID(stale.ID)
+		if warned.InactivityWarnedAt == nil {
+			t.Fatal("ticket was not marked as warned")
+		}
+
+		if err := store.Tickets().Touch(stale.ID, now); err != nil {
+			t.Fatalf("Touch: %v", err)
+		}
+		touched, _ := store.Tickets().FindByID(stale.ID)
+		if touched.InactivityWarnedAt != nil {
+			t.Errorf("warning at %v is kept after activity, want none", touched.InactivityWarnedAt)
+		}
+	})
+}
+
+func TestTicketRepositoryCountOpenByClaimer(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		claims := []struct {
+			user   discord.UserID
+			staff  discord.UserID
+			closed bool
+		}{
+			{user: 1, staff: 50},
+			{user: 2, staff: 50},
+			{user: 3, staff: 51},
+			{user: 4, staff: 51, closed: true},
+			{user: 5},
+		}
+
+		for i, claim := range claims {
+			ticket := mustCreateTicket(t, store, claim.user, discord.ChannelID(100+i))
+
+			if claim.staff.IsValid() {
+				if err := store.Tickets().Assign(ticket.ID, claim.staff); err != nil {
+					t.Fatalf("Assign: %v", err)
+				}
+			}
+			if claim.closed {
+				if err := store.Tickets().Close(ticket.ID, claim.staff, ""); err != nil {
+					t.Fatalf("Close: %v", err)
+				}
+			}
+		}
+
+		counts, err := store.Tickets().CountOpenByClaimer()
+		if err != nil {
+			t.Fatalf("CountOpenByClaimer: %v", err)
+		}
+
+		want := map[discord.UserID]int{50: 2, 51: 1}
+		if len(counts) != len(want) || counts[50] != want[50] || counts[51] != want[51] {
+			t.Errorf("open tickets by claimer = %v, want %v", counts, want)
+		}
+	})
+}
+
+func TestTagRepository(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		first := mustCreateTicket(t, store, 1, 10)
+		second := mustCreateTicket(t, store, 2, 11)
+		third := mustCreateTicket(t, store, 3, 12)
+
+		adds := []struct {
+			ticket int64
+			tag    string
+			added  bool
+		}{
+			{first.ID, "billing", true},
+			{first.ID, "bug", true},
+			{first.ID, "billing", false},
+			{second.ID, "billing", true},
+			{third.ID, "billing", true},
+			{third.ID, "abuse", true},
+		}
+		for _, add := range adds {
+			added, err := store.Tags().Add(add.ticket, add.tag)
+			if err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			if added != add.added {
+				t.Errorf("Add(%d, %q) = %v, want %v", add.ticket, add.tag, added, add.added)
+			}
+		}
+
+		tags, err := store.Tags().ListByTicket(first.ID)
+		if err != nil {
+			t.Fatalf("ListByTicket: %v", err)
+		}
+		if want := []string{"billing", "bug"}; !slices.Equal(tags, want) {
+			t.Errorf("tags = %v, want %v", tags, want)
+		}
+
+		counts, err := store.Tags().Count()
+		if err != nil {
+			t.Fatalf("Count: %v", err)
+		}
+		want := []TagCount{{"billing", 3}, {"abuse", 1}, {"bug", 1}}
+		if !slices.Equal(counts, want) {
+			t.Errorf("tag counts = %v, want %v", counts, want)
+		}
+
+		found, err := store.Tags().FindTickets("billing", 2)
+		if err != nil {
+			t.Fatalf("FindTickets: %v", err)
+		}
+		if got, want := ticketIDs(found), []int64{third.ID, second.ID}; !slices.Equal(got, want) {
+			t.Errorf("tickets tagged billing = %v, want %v", got, want)
+		}
+
+		removed, err := store.Tags().Remove(first.ID, "bug")
+		if err != nil || !removed {
+			t.Errorf("Remove of a tag = %v, %v, want true", removed, err)
+		}
+		removed, err = store.Tags().Remove(first.ID, "bug")
+		if err != nil || removed {
+			t.Errorf("Remove of a missing tag = %v, %v, want false", removed, err)
+		}
+	})
+}
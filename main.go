@@ -19,19 +19,17 @@ func main() {
 		log.Fatalf("Error loading config: %v", err)
 	}
 
-	// Pass the configuration to the database connection function
-	db, err := database.Connect(cfg)
+	// Pass the configuration to the storage connection function
+	store, err := database.Connect(cfg)
 	if err != nil {
 		log.Fatalf("Error connecting to the database: %v", err)
 		return
 	}
 
-	_ = db
-
 	// Initialize language system
 	if err := language.InitializeLanguage("languages"); err != nil {
 		log.Fatalf("Failed to initialize language system: %v", err)
 	}
 
-	bot.InitializeBot(cfg)
+	bot.InitializeBot(cfg, store)
 }